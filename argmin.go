@@ -0,0 +1,222 @@
+package vec
+
+import (
+	"errors"
+
+	"github.com/ncw/gmp"
+)
+
+// ArgMinTuple is one party's share of the preprocessing for ArgMin over a
+// fixed number of candidates with one comparison tuple and one
+// multiplication triple per level of the tournament
+type ArgMinTuple struct {
+	Comparisons []*ComparisonTuple
+	Triples     []*BeaverTriple
+}
+
+// ArgMinShare is a party's share of the result of ArgMin
+type ArgMinShare struct {
+	OneHot *ShareVec // one-hot encoding of the index of the minimum
+	Index  *gmp.Int  // share of the index of the minimum
+	Min    *gmp.Int  // share of the minimum
+}
+
+// NewArgMinTuples returns additive shares of the preprocessing for ArgMin over
+// numCandidates values for each of the parties (trusted dealer)
+// where the values have absolute value less than 2^(bits-2)
+func NewArgMinTuples(numCandidates int, bits int, numParties int, p *gmp.Int) []*ArgMinTuple {
+
+	tuples := make([]*ArgMinTuple, numParties)
+	for i := range tuples {
+		tuples[i] = &ArgMinTuple{}
+	}
+
+	for _, pairs := range tournamentPairs(numCandidates) {
+		comparisons := NewComparisonTuples(pairs, bits, numParties, p)
+		triples := NewBeaverTriples(pairs*(numCandidates+1), numParties, p)
+
+		for i := range tuples {
+			tuples[i].Comparisons = append(tuples[i].Comparisons, comparisons[i])
+			tuples[i].Triples = append(tuples[i].Triples, triples[i])
+		}
+	}
+
+	return tuples
+}
+
+// NewTopKTuples returns additive shares of the preprocessing for TopK over
+// numCandidates values for each of the parties (trusted dealer)
+// indexed by party and then by round
+func NewTopKTuples(numCandidates int, k int, bits int, numParties int, p *gmp.Int) [][]*ArgMinTuple {
+
+	tuples := make([][]*ArgMinTuple, numParties)
+	for round := 0; round < k; round++ {
+		for i, tuple := range NewArgMinTuples(numCandidates, bits, numParties, p) {
+			tuples[i] = append(tuples[i], tuple)
+		}
+	}
+
+	return tuples
+}
+
+// ArgMin returns the party's share of the index (both as a one-hot vector and as an integer)
+// and value of the minimum of the shared values, where the values have absolute value
+// less than 2^(bits-2). Ties are broken in favour of the smallest index.
+// The minimum is found with a tournament of secure comparisons
+// taking a logarithmic number of comparison rounds
+func (p *Party) ArgMin(d *ShareVec, bits int, tuple *ArgMinTuple) (*ArgMinShare, error) {
+
+	n := d.Vec.Size()
+	if n == 0 {
+		return nil, errors.New("cannot take the minimum of an empty vector")
+	}
+
+	if d.Index != p.ID {
+		return nil, errors.New("index of share does not match party ID")
+	}
+
+	levels := tournamentPairs(n)
+	if len(tuple.Comparisons) != len(levels) || len(tuple.Triples) != len(levels) {
+		return nil, errors.New("argmin tuple does not match the number of candidates")
+	}
+
+	field := d.P
+
+	// remaining candidates with their values and one-hot indices
+	values := make([]*gmp.Int, n)
+	oneHots := make([]*BigVec, n)
+	for j := range values {
+		values[j] = new(gmp.Int).Set(d.Vec.Coords[j])
+
+		oneHots[j] = NewBigZeroVec(n)
+		if p.ID == 0 {
+			oneHots[j].Coords[j].SetInt64(1)
+		}
+	}
+
+	for level, pairs := range levels {
+
+		left := NewBigZeroVec(pairs)
+		right := NewBigZeroVec(pairs)
+		for i := 0; i < pairs; i++ {
+			left.Coords[i].Set(values[2*i])
+			right.Coords[i].Set(values[2*i+1])
+		}
+
+		// b = [right < left] selects the right candidate
+		b, err := p.LessThan(&ShareVec{right, field, p.ID}, &ShareVec{left, field, p.ID}, bits, tuple.Comparisons[level])
+		if err != nil {
+			return nil, err
+		}
+
+		// multiply b by the differences of the values and one-hot indices of every pair
+		x := NewBigZeroVec(pairs * (n + 1))
+		y := NewBigZeroVec(pairs * (n + 1))
+		for i := 0; i < pairs; i++ {
+			offset := i * (n + 1)
+
+			x.Coords[offset].Set(b.Vec.Coords[i])
+			y.Coords[offset].Sub(values[2*i+1], values[2*i])
+
+			for j := 0; j < n; j++ {
+				x.Coords[offset+1+j].Set(b.Vec.Coords[i])
+				y.Coords[offset+1+j].Sub(oneHots[2*i+1].Coords[j], oneHots[2*i].Coords[j])
+			}
+		}
+
+		prod, err := p.Mul(&ShareVec{x, field, p.ID}, &ShareVec{y.Mod(field), field, p.ID}, tuple.Triples[level])
+		if err != nil {
+			return nil, err
+		}
+
+		// the winner of a pair is left + b * (right - left)
+		nextValues := make([]*gmp.Int, 0, len(values)-pairs)
+		nextOneHots := make([]*BigVec, 0, len(values)-pairs)
+		for i := 0; i < pairs; i++ {
+			offset := i * (n + 1)
+
+			value := new(gmp.Int).Add(values[2*i], prod.Vec.Coords[offset])
+			nextValues = append(nextValues, value.Mod(value, field))
+
+			oneHot := oneHots[2*i].Clone()
+			for j := 0; j < n; j++ {
+				oneHot.Coords[j].Add(oneHot.Coords[j], prod.Vec.Coords[offset+1+j])
+			}
+			nextOneHots = append(nextOneHots, oneHot.Mod(field))
+		}
+
+		// an odd candidate out advances to the next level
+		if len(values)%2 == 1 {
+			nextValues = append(nextValues, values[len(values)-1])
+			nextOneHots = append(nextOneHots, oneHots[len(oneHots)-1])
+		}
+
+		values = nextValues
+		oneHots = nextOneHots
+	}
+
+	// the index is sum_j j * oneHot_j
+	index := gmp.NewInt(0)
+	for j, coord := range oneHots[0].Coords {
+		index.Add(index, new(gmp.Int).Mul(gmp.NewInt(int64(j)), coord))
+	}
+
+	return &ArgMinShare{
+		OneHot: &ShareVec{oneHots[0], field, p.ID},
+		Index:  index.Mod(index, field),
+		Min:    values[0],
+	}, nil
+}
+
+// TopK returns the party's shares of the k smallest of the shared values in increasing order
+// (see ArgMin) using one ArgMinTuple per round, where the values have absolute value
+// less than 2^(bits-2). After every round the winner is excluded by adding 2^(bits-1) to its value
+func (p *Party) TopK(d *ShareVec, k int, bits int, tuples []*ArgMinTuple) ([]*ArgMinShare, error) {
+
+	if k < 1 || k > d.Vec.Size() {
+		return nil, errors.New("k must be between 1 and the number of candidates")
+	}
+
+	if bits < 2 {
+		return nil, errors.New("bit length must be at least 2")
+	}
+
+	if len(tuples) < k {
+		return nil, errors.New("not enough argmin tuples for k rounds")
+	}
+
+	penalty := new(gmp.Int).Lsh(gmp.NewInt(1), uint(bits-1))
+
+	res := make([]*ArgMinShare, k)
+	current := &ShareVec{d.Vec.Clone(), d.P, d.Index}
+	for round := 0; round < k; round++ {
+
+		min, err := p.ArgMin(current, bits, tuples[round])
+		if err != nil {
+			return nil, err
+		}
+
+		// the winner has not been penalized so its value is the true minimum
+		res[round] = min
+
+		next := current.Vec.Clone()
+		for j, coord := range min.OneHot.Vec.Coords {
+			next.Coords[j].Add(next.Coords[j], new(gmp.Int).Mul(penalty, coord))
+		}
+
+		current = &ShareVec{next.Mod(d.P), d.P, d.Index}
+	}
+
+	return res, nil
+}
+
+// tournamentPairs returns the number of pairs compared at
+// every level of a tournament over n candidates
+func tournamentPairs(n int) []int {
+	var levels []int
+	for n > 1 {
+		levels = append(levels, n/2)
+		n -= n / 2
+	}
+	return levels
+}
@@ -0,0 +1,133 @@
+package vec
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/ncw/gmp"
+)
+
+func TestPartyArgMin(t *testing.T) {
+
+	field := randomPrime(128)
+	numParties := 3
+	bits := 24
+
+	for _, n := range []int{1, 2, 7, 16} {
+		for trial := 0; trial < 3; trial++ {
+
+			d := NewBigRandomVec(n, gmp.NewInt(-1000), gmp.NewInt(1000))
+			shares := SecretShare(d, numParties, field)
+			tuples := NewArgMinTuples(n, bits, numParties, field)
+
+			res := make([]*ArgMinShare, numParties)
+			runParties(t, memoryTransports(numParties), func(party *Party) error {
+				var err error
+				res[party.ID], err = party.ArgMin(shares[party.ID], bits, tuples[party.ID])
+				return err
+			})
+
+			expected := 0
+			for j := range d.Coords {
+				if d.Coords[j].Cmp(d.Coords[expected]) < 0 {
+					expected = j
+				}
+			}
+
+			checkArgMin(t, res, field, d, expected)
+		}
+	}
+}
+
+func TestPartyArgMinTies(t *testing.T) {
+
+	field := randomPrime(128)
+	numParties := 2
+	bits := 24
+
+	d := NewBigVec([]*gmp.Int{gmp.NewInt(5), gmp.NewInt(-3), gmp.NewInt(7), gmp.NewInt(-3), gmp.NewInt(-3)})
+	shares := SecretShare(d, numParties, field)
+	tuples := NewArgMinTuples(d.Size(), bits, numParties, field)
+
+	res := make([]*ArgMinShare, numParties)
+	runParties(t, memoryTransports(numParties), func(party *Party) error {
+		var err error
+		res[party.ID], err = party.ArgMin(shares[party.ID], bits, tuples[party.ID])
+		return err
+	})
+
+	checkArgMin(t, res, field, d, 1)
+}
+
+func TestPartyTopK(t *testing.T) {
+
+	field := randomPrime(128)
+	numParties := 3
+	bits := 24
+	n := 10
+	k := 4
+
+	for trial := 0; trial < 3; trial++ {
+
+		d := NewBigRandomVec(n, gmp.NewInt(0), gmp.NewInt(1000))
+		shares := SecretShare(d, numParties, field)
+		tuples := NewTopKTuples(n, k, bits, numParties, field)
+
+		res := make([][]*ArgMinShare, numParties)
+		runParties(t, memoryTransports(numParties), func(party *Party) error {
+			var err error
+			res[party.ID], err = party.TopK(shares[party.ID], k, bits, tuples[party.ID])
+			return err
+		})
+
+		// expected indices in increasing order of value (stable for ties)
+		order := make([]int, n)
+		for j := range order {
+			order[j] = j
+		}
+		sort.SliceStable(order, func(a, b int) bool {
+			return d.Coords[order[a]].Cmp(d.Coords[order[b]]) < 0
+		})
+
+		for round := 0; round < k; round++ {
+			shares := make([]*ArgMinShare, numParties)
+			for i := range shares {
+				shares[i] = res[i][round]
+			}
+
+			checkArgMin(t, shares, field, d, order[round])
+		}
+	}
+}
+
+// checkArgMin checks that the shares of the ArgMin result open to the expected index
+func checkArgMin(t *testing.T, res []*ArgMinShare, field *gmp.Int, d *BigVec, expected int) {
+
+	oneHots := make([]*ShareVec, len(res))
+	indices := make([]*gmp.Int, len(res))
+	mins := make([]*gmp.Int, len(res))
+	for i, share := range res {
+		oneHots[i] = share.OneHot
+		indices[i] = share.Index
+		mins[i] = share.Min
+	}
+
+	oneHot, err := RecoverVector(oneHots...)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for j, coord := range oneHot.Coords {
+		if (j == expected && coord.Int64() != 1) || (j != expected && coord.Sign() != 0) {
+			t.Fatalf("Incorrest result. Expected one-hot at %v, got %v", expected, oneHot)
+		}
+	}
+
+	if index := RecoverInt(field, indices...); index.Int64() != int64(expected) {
+		t.Fatalf("Incorrest result. Expected index %v, got %v", expected, index)
+	}
+
+	if min := RecoverInt(field, mins...); min.Cmp(d.Coords[expected]) != 0 {
+		t.Fatalf("Incorrest result. Expected minimum %v, got %v", d.Coords[expected], min)
+	}
+}
@@ -0,0 +1,315 @@
+package vec
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+	"errors"
+
+	"github.com/ncw/gmp"
+)
+
+// ErrMACCheckFailed is returned when the MACs of opened shares are inconsistent,
+// meaning at least one party has modified its share
+var ErrMACCheckFailed = errors.New("MAC check failed: shares have been tampered with")
+
+// MACKeyShare is a party's additive share of the global MAC key alpha
+type MACKeyShare struct {
+	Alpha *gmp.Int
+	P     *gmp.Int
+	Index int // share number
+}
+
+// AuthShareVec is a secret share of a vector together with a share
+// of the information-theoretic MAC alpha * x of every coordinate
+type AuthShareVec struct {
+	Share *ShareVec
+	MAC   *BigVec
+}
+
+// NewMACKeyShares returns additive shares of a random global MAC key
+func NewMACKeyShares(numShares int, p *gmp.Int) []*MACKeyShare {
+
+	alpha := NewBigRandomVec(1, gmp.NewInt(0), p)
+	shares := SecretShare(alpha, numShares, p)
+
+	keys := make([]*MACKeyShare, numShares)
+	for i, share := range shares {
+		keys[i] = &MACKeyShare{share.Vec.Coord(0), p, share.Index}
+	}
+
+	return keys
+}
+
+// AuthSecretShare returns authenticated secret shares of the vector
+// under the global MAC key shared in keys (trusted dealer)
+func AuthSecretShare(a *BigVec, keys []*MACKeyShare) []*AuthShareVec {
+
+	p := keys[0].P
+	alpha := macKey(keys)
+
+	mac := a.Clone()
+	for _, coord := range mac.Coords {
+		coord.Mul(coord, alpha)
+	}
+	mac.Mod(p)
+
+	shares := SecretShare(a.Clone().Mod(p), len(keys), p)
+	macShares := SecretShare(mac, len(keys), p)
+
+	res := make([]*AuthShareVec, len(keys))
+	for i := range keys {
+		res[i] = &AuthShareVec{shares[i], macShares[i].Vec}
+	}
+
+	return res
+}
+
+// Add returns the component-wise addition of a and b
+// throws an error if the vectors are of different size
+func (a *AuthShareVec) Add(b *AuthShareVec) (*AuthShareVec, error) {
+
+	share, err := a.Share.Add(b.Share)
+	if err != nil {
+		return nil, err
+	}
+
+	mac, err := a.MAC.Add(b.MAC)
+	if err != nil {
+		return nil, err
+	}
+
+	return &AuthShareVec{share, mac.Mod(share.P)}, nil
+}
+
+// Sub returns the component-wise subtaction of a and b
+// throws an error if the vectors are of different size
+func (a *AuthShareVec) Sub(b *AuthShareVec) (*AuthShareVec, error) {
+
+	share, err := a.Share.Sub(b.Share)
+	if err != nil {
+		return nil, err
+	}
+
+	mac, err := a.MAC.Sub(b.MAC)
+	if err != nil {
+		return nil, err
+	}
+
+	return &AuthShareVec{share, mac.Mod(share.P)}, nil
+}
+
+// Mul returns the component-wise multiplication of a and the public vector b
+// throws an error if the vectors are of different size
+func (a *AuthShareVec) Mul(b *BigVec) (*AuthShareVec, error) {
+
+	share, err := a.Share.Mul(b)
+	if err != nil {
+		return nil, err
+	}
+
+	mac, err := a.MAC.Mul(b)
+	if err != nil {
+		return nil, err
+	}
+
+	return &AuthShareVec{share, mac.Mod(share.P)}, nil
+}
+
+// Dot returns an authenticated share of the dot product of a and
+// the public vector b as a vector with a single coordinate
+func (a *AuthShareVec) Dot(b *BigVec) (*AuthShareVec, error) {
+
+	share, err := a.Share.Dot(b)
+	if err != nil {
+		return nil, err
+	}
+
+	mac, err := a.MAC.Dot(b)
+	if err != nil {
+		return nil, err
+	}
+
+	mac.Mod(mac, a.Share.P)
+
+	return &AuthShareVec{
+		&ShareVec{NewBigVec([]*gmp.Int{share}), a.Share.P, a.Share.Index},
+		NewBigVec([]*gmp.Int{mac}),
+	}, nil
+}
+
+// AddPublic returns the addition of a and the public vector b
+// where the party with index 0 adds b to its share
+func (a *AuthShareVec) AddPublic(b *BigVec, key *MACKeyShare) (*AuthShareVec, error) {
+
+	if a.Share.Index != key.Index {
+		return nil, errors.New("index of share does not match index of MAC key")
+	}
+
+	if len(a.MAC.Coords) != len(b.Coords) {
+		return nil, errors.New("cannot add different sized vectors")
+	}
+
+	share := a.Share.Vec.Clone()
+	if a.Share.Index == 0 {
+		share.Add(b)
+	}
+
+	mac := a.MAC.Clone()
+	for i, coord := range b.Coords {
+		mac.Coords[i].Add(mac.Coords[i], new(gmp.Int).Mul(coord, key.Alpha))
+	}
+
+	return &AuthShareVec{
+		&ShareVec{share.Mod(a.Share.P), a.Share.P, a.Share.Index},
+		mac.Mod(a.Share.P),
+	}, nil
+}
+
+// VerifiedOpen outputs the recovered BigVec from the set of authenticated shares
+// with coordinates in [0, p) like OpenVector
+// returns ErrMACCheckFailed if the MACs do not match the recovered vector
+func VerifiedOpen(shares []*AuthShareVec, keys []*MACKeyShare) (*BigVec, error) {
+
+	if len(shares) != len(keys) {
+		return nil, errors.New("number of shares does not match number of MAC keys")
+	}
+
+	plain := make([]*ShareVec, len(shares))
+	for i, share := range shares {
+		plain[i] = share.Share
+	}
+
+	x, err := OpenVector(plain...)
+	if err != nil {
+		return nil, err
+	}
+
+	sigma := NewBigZeroVec(x.Size())
+	for i, share := range shares {
+		check, err := macCheckShare(share, keys[i], x)
+		if err != nil {
+			return nil, err
+		}
+
+		sigma.Add(check)
+	}
+
+	if !sigma.Mod(shares[0].Share.P).Equal(NewBigZeroVec(x.Size())) {
+		return nil, ErrMACCheckFailed
+	}
+
+	return x, nil
+}
+
+// VerifiedOpen reveals the vector shared among all parties in authenticated shares
+// with coordinates in [0, p) like OpenVector.
+// Each party commits to its MAC check value before revealing it so that
+// no party can adapt its check value to the others'.
+// Returns ErrMACCheckFailed if the MACs do not match the opened vector
+func (p *Party) VerifiedOpen(share *AuthShareVec, key *MACKeyShare) (*BigVec, error) {
+
+	if share.Share.Index != p.ID {
+		return nil, errors.New("index of share does not match party ID")
+	}
+
+	vectors, err := p.Exchange(share.Share.Vec)
+	if err != nil {
+		return nil, err
+	}
+
+	shares := make([]*ShareVec, len(vectors))
+	for i, v := range vectors {
+		if v.Size() != share.Share.Vec.Size() {
+			return nil, errors.New("received share of a different size")
+		}
+
+		shares[i] = &ShareVec{v, share.Share.P, i}
+	}
+
+	x, err := OpenVector(shares...)
+	if err != nil {
+		return nil, err
+	}
+
+	sigma, err := macCheckShare(share, key, x)
+	if err != nil {
+		return nil, err
+	}
+
+	opening, err := sigma.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, 32)
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+
+	opening = append(nonce, opening...)
+	commitment := sha256.Sum256(opening)
+
+	commitments, err := p.exchangeBytes(commitment[:])
+	if err != nil {
+		return nil, err
+	}
+
+	openings, err := p.exchangeBytes(opening)
+	if err != nil {
+		return nil, err
+	}
+
+	total := NewBigZeroVec(x.Size())
+	for i := range openings {
+		digest := sha256.Sum256(openings[i])
+		if !bytes.Equal(digest[:], commitments[i]) || len(openings[i]) < len(nonce) {
+			return nil, ErrMACCheckFailed
+		}
+
+		check := &BigVec{}
+		if err := check.UnmarshalBinary(openings[i][len(nonce):]); err != nil {
+			return nil, ErrMACCheckFailed
+		}
+
+		if _, err := total.Add(check); err != nil {
+			return nil, ErrMACCheckFailed
+		}
+	}
+
+	if !total.Mod(share.Share.P).Equal(NewBigZeroVec(x.Size())) {
+		return nil, ErrMACCheckFailed
+	}
+
+	return x, nil
+}
+
+// macCheckShare returns the party's share of alpha * x - MAC(x)
+// which sums to zero over all parties if the shares are consistent
+func macCheckShare(share *AuthShareVec, key *MACKeyShare, x *BigVec) (*BigVec, error) {
+
+	if share.Share.Index != key.Index {
+		return nil, errors.New("index of share does not match index of MAC key")
+	}
+
+	if len(share.MAC.Coords) != len(x.Coords) {
+		return nil, errors.New("MAC of a different size than the vector")
+	}
+
+	sigma := NewBigZeroVec(x.Size())
+	for i, coord := range x.Coords {
+		sigma.Coords[i].Mul(coord, key.Alpha)
+		sigma.Coords[i].Sub(sigma.Coords[i], share.MAC.Coords[i])
+	}
+
+	return sigma.Mod(key.P), nil
+}
+
+// macKey returns the global MAC key from its shares
+func macKey(keys []*MACKeyShare) *gmp.Int {
+	alpha := gmp.NewInt(0)
+	for _, key := range keys {
+		alpha.Add(alpha, key.Alpha)
+	}
+	return alpha.Mod(alpha, keys[0].P)
+}
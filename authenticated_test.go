@@ -0,0 +1,145 @@
+package vec
+
+import (
+	"testing"
+
+	"github.com/ncw/gmp"
+)
+
+func TestAuthSecretShare(t *testing.T) {
+
+	field := randomPrime(100)
+
+	for trial := 0; trial < 100; trial++ {
+
+		keys := NewMACKeyShares(3, field)
+		aBig := NewBigRandomVec(dim, gmp.NewInt(-1000), gmp.NewInt(1000))
+		shares := AuthSecretShare(aBig, keys)
+
+		res, err := VerifiedOpen(shares, keys)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if res = res.DecodeSignedValues(field); !res.Equal(aBig) {
+			t.Fatalf("Incorrest result. Expected %v got %v", aBig, res)
+		}
+	}
+}
+
+func TestAuthSecretShareLinear(t *testing.T) {
+
+	field := randomPrime(100)
+
+	for trial := 0; trial < 100; trial++ {
+
+		keys := NewMACKeyShares(2, field)
+		aBig := NewBigRandomVec(dim, gmp.NewInt(-100), gmp.NewInt(100))
+		bBig := NewBigRandomVec(dim, gmp.NewInt(-100), gmp.NewInt(100))
+		cBig := NewBigRandomVec(dim, gmp.NewInt(-100), gmp.NewInt(100))
+		sharesA := AuthSecretShare(aBig, keys)
+		sharesB := AuthSecretShare(bBig, keys)
+
+		res := make([]*AuthShareVec, 2)
+		dots := make([]*AuthShareVec, 2)
+		for i := range keys {
+			diff, err := sharesA[i].Sub(sharesB[i])
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if dots[i], err = diff.Dot(cBig); err != nil {
+				t.Fatal(err)
+			}
+
+			if res[i], err = diff.Mul(cBig); err != nil {
+				t.Fatal(err)
+			}
+
+			if res[i], err = res[i].AddPublic(cBig, keys[i]); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		expectedDiff, _ := aBig.Clone().Sub(bBig)
+		expectedDot, _ := expectedDiff.Dot(cBig)
+		expected, _ := expectedDiff.Mul(cBig)
+		expected.Add(cBig)
+
+		got, err := VerifiedOpen(res, keys)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if got = got.DecodeSignedValues(field); !got.Equal(expected) {
+			t.Fatalf("Incorrest result. \nExpected %v \nGot %v", expected, got)
+		}
+
+		got, err = VerifiedOpen(dots, keys)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if got = got.DecodeSignedValues(field); got.Coord(0).Cmp(expectedDot) != 0 {
+			t.Fatalf("Incorrest result. Expected %v, got %v", expectedDot, got)
+		}
+	}
+}
+
+func TestVerifiedOpenDetectsCheating(t *testing.T) {
+
+	field := randomPrime(100)
+
+	for trial := 0; trial < 100; trial++ {
+
+		keys := NewMACKeyShares(3, field)
+		aBig := NewBigRandomVec(dim, gmp.NewInt(-1000), gmp.NewInt(1000))
+		shares := AuthSecretShare(aBig, keys)
+
+		// party 1 shifts its share of one coordinate
+		shares[1].Share.Vec.Coords[trial%dim].Add(shares[1].Share.Vec.Coords[trial%dim], gmp.NewInt(1))
+
+		if _, err := VerifiedOpen(shares, keys); err != ErrMACCheckFailed {
+			t.Fatalf("Expected MAC check failure, got %v", err)
+		}
+	}
+}
+
+func TestPartyVerifiedOpen(t *testing.T) {
+
+	field := randomPrime(100)
+	transports := memoryTransports(3)
+	defer transports[0].Close()
+
+	for trial := 0; trial < 10; trial++ {
+
+		keys := NewMACKeyShares(3, field)
+		aBig := NewBigRandomVec(dim, gmp.NewInt(-1000), gmp.NewInt(1000))
+		shares := AuthSecretShare(aBig, keys)
+		cheat := trial%2 == 1
+
+		if cheat {
+			shares[2].Share.Vec.Coords[0].Add(shares[2].Share.Vec.Coords[0], gmp.NewInt(1))
+		}
+
+		runParties(t, transports, func(party *Party) error {
+			res, err := party.VerifiedOpen(shares[party.ID], keys[party.ID])
+			if cheat {
+				if err != ErrMACCheckFailed {
+					t.Errorf("Expected MAC check failure, got %v", err)
+				}
+				return nil
+			}
+
+			if err != nil {
+				return err
+			}
+
+			if res = res.DecodeSignedValues(field); !res.Equal(aBig) {
+				t.Errorf("Incorrest result. Expected %v got %v", aBig, res)
+			}
+
+			return nil
+		})
+	}
+}
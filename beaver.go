@@ -0,0 +1,184 @@
+package vec
+
+import (
+	"errors"
+
+	"github.com/ncw/gmp"
+)
+
+// BeaverTriple is one party's share of a random multiplication
+// triple (a, b, c) such that c = a * b component-wise
+type BeaverTriple struct {
+	A *ShareVec
+	B *ShareVec
+	C *ShareVec
+}
+
+// NewBeaverTriples returns additive shares of a random multiplication triple
+// of the given dimension for each of the parties (trusted dealer)
+func NewBeaverTriples(dim int, numParties int, p *gmp.Int) []*BeaverTriple {
+
+	a := NewBigRandomVec(dim, gmp.NewInt(0), p)
+	b := NewBigRandomVec(dim, gmp.NewInt(0), p)
+	c, _ := a.Clone().Mul(b)
+	c.Mod(p)
+
+	sharesA := SecretShare(a, numParties, p)
+	sharesB := SecretShare(b, numParties, p)
+	sharesC := SecretShare(c, numParties, p)
+
+	triples := make([]*BeaverTriple, numParties)
+	for i := 0; i < numParties; i++ {
+		triples[i] = &BeaverTriple{sharesA[i], sharesB[i], sharesC[i]}
+	}
+
+	return triples
+}
+
+// OpenVector outputs the vector in Z_p reconstructed from the set of additive shares
+// without decoding signed values
+func OpenVector(shares ...*ShareVec) (*BigVec, error) {
+
+	if len(shares) == 0 {
+		return nil, errors.New("no shares provided")
+	}
+
+	res := NewBigZeroVec(len(shares[0].Vec.Coords))
+	for _, share := range shares {
+		if _, err := res.Add(share.Vec); err != nil {
+			return nil, err
+		}
+	}
+
+	return res.Mod(shares[0].P), nil
+}
+
+// BeaverMask returns the party's shares of d = x - a and e = y - b
+// which are opened by all parties before calling BeaverMul
+func BeaverMask(x *ShareVec, y *ShareVec, triple *BeaverTriple) (*ShareVec, *ShareVec, error) {
+
+	if x.Index != triple.A.Index || y.Index != triple.A.Index {
+		return nil, nil, errors.New("index of shares does not match index of triple")
+	}
+
+	d, err := x.Vec.Clone().Sub(triple.A.Vec)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	e, err := y.Vec.Clone().Sub(triple.B.Vec)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return &ShareVec{d.Mod(x.P), x.P, x.Index}, &ShareVec{e.Mod(x.P), x.P, x.Index}, nil
+}
+
+// BeaverMul returns the party's share of the component-wise product x * y
+// given the opened values d = x - a and e = y - b
+func BeaverMul(triple *BeaverTriple, d *BigVec, e *BigVec) (*ShareVec, error) {
+
+	p := triple.C.P
+	dim := triple.C.Vec.Size()
+
+	if d.Size() != dim || e.Size() != dim {
+		return nil, errors.New("cannot multiply different sized vectors")
+	}
+
+	// z = c + d*b + e*a (+ d*e for the party with index 0)
+	z := triple.C.Vec.Clone()
+	for i := 0; i < dim; i++ {
+		z.Coords[i].Add(z.Coords[i], new(gmp.Int).Mul(d.Coords[i], triple.B.Vec.Coords[i]))
+		z.Coords[i].Add(z.Coords[i], new(gmp.Int).Mul(e.Coords[i], triple.A.Vec.Coords[i]))
+
+		if triple.C.Index == 0 {
+			z.Coords[i].Add(z.Coords[i], new(gmp.Int).Mul(d.Coords[i], e.Coords[i]))
+		}
+	}
+
+	return &ShareVec{z.Mod(p), p, triple.C.Index}, nil
+}
+
+// BeaverDot returns the party's share of the dot product of x and y
+// given the opened values d = x - a and e = y - b
+func BeaverDot(triple *BeaverTriple, d *BigVec, e *BigVec) (*gmp.Int, error) {
+
+	z, err := BeaverMul(triple, d, e)
+	if err != nil {
+		return nil, err
+	}
+
+	res := gmp.NewInt(0)
+	for _, coord := range z.Vec.Coords {
+		res.Add(res, coord)
+	}
+
+	return res.Mod(res, z.P), nil
+}
+
+// MulShares returns shares of the component-wise product of the vectors
+// shared in xs and ys, simulating all parties in-process
+func MulShares(xs []*ShareVec, ys []*ShareVec, triples []*BeaverTriple) ([]*ShareVec, error) {
+
+	d, e, err := openBeaverMasks(xs, ys, triples)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*ShareVec, len(triples))
+	for i, triple := range triples {
+		if res[i], err = BeaverMul(triple, d, e); err != nil {
+			return nil, err
+		}
+	}
+
+	return res, nil
+}
+
+// DotShares returns shares of the dot product of the vectors
+// shared in xs and ys, simulating all parties in-process
+func DotShares(xs []*ShareVec, ys []*ShareVec, triples []*BeaverTriple) ([]*gmp.Int, error) {
+
+	d, e, err := openBeaverMasks(xs, ys, triples)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*gmp.Int, len(triples))
+	for i, triple := range triples {
+		if res[i], err = BeaverDot(triple, d, e); err != nil {
+			return nil, err
+		}
+	}
+
+	return res, nil
+}
+
+// openBeaverMasks computes and opens d = x - a and e = y - b for all parties
+func openBeaverMasks(xs []*ShareVec, ys []*ShareVec, triples []*BeaverTriple) (*BigVec, *BigVec, error) {
+
+	if len(xs) != len(triples) || len(ys) != len(triples) {
+		return nil, nil, errors.New("number of shares does not match number of triples")
+	}
+
+	sharesD := make([]*ShareVec, len(triples))
+	sharesE := make([]*ShareVec, len(triples))
+	for i, triple := range triples {
+		var err error
+		if sharesD[i], sharesE[i], err = BeaverMask(xs[i], ys[i], triple); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	d, err := OpenVector(sharesD...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	e, err := OpenVector(sharesE...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return d, e, nil
+}
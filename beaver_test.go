@@ -0,0 +1,86 @@
+package vec
+
+import (
+	"testing"
+
+	"github.com/ncw/gmp"
+)
+
+func TestBeaverTriples(t *testing.T) {
+
+	field := randomPrime(100)
+
+	for trial := 0; trial < 10; trial++ {
+		triples := NewBeaverTriples(dim, 3, field)
+
+		sharesA := make([]*ShareVec, 3)
+		sharesB := make([]*ShareVec, 3)
+		sharesC := make([]*ShareVec, 3)
+		for i, triple := range triples {
+			sharesA[i], sharesB[i], sharesC[i] = triple.A, triple.B, triple.C
+		}
+
+		a, _ := OpenVector(sharesA...)
+		b, _ := OpenVector(sharesB...)
+		c, _ := OpenVector(sharesC...)
+
+		expected, _ := a.Mul(b)
+		if !expected.Mod(field).Equal(c) {
+			t.Fatalf("Invalid triple. \nExpected %v \nGot %v", expected, c)
+		}
+	}
+}
+
+func TestBeaverMulShares(t *testing.T) {
+
+	field := randomPrime(100)
+
+	for trial := 0; trial < 100; trial++ {
+
+		aBig := NewBigRandomVec(dim, gmp.NewInt(-1000), gmp.NewInt(1000))
+		bBig := NewBigRandomVec(dim, gmp.NewInt(-1000), gmp.NewInt(1000))
+		sharesA := SecretShare(aBig, 3, field)
+		sharesB := SecretShare(bBig, 3, field)
+		triples := NewBeaverTriples(dim, 3, field)
+
+		prods, err := MulShares(sharesA, sharesB, triples)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		res, err := RecoverVector(prods...)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		expected, _ := aBig.Clone().Mul(bBig)
+		if !res.Equal(expected) {
+			t.Fatalf("Incorrest result. \nExpected %v \nGot %v", expected, res)
+		}
+	}
+}
+
+func TestBeaverDotShares(t *testing.T) {
+
+	field := randomPrime(100)
+
+	for trial := 0; trial < 100; trial++ {
+
+		aBig := NewBigRandomVec(dim, gmp.NewInt(-1000), gmp.NewInt(1000))
+		bBig := NewBigRandomVec(dim, gmp.NewInt(-1000), gmp.NewInt(1000))
+		sharesA := SecretShare(aBig, 2, field)
+		sharesB := SecretShare(bBig, 2, field)
+		triples := NewBeaverTriples(dim, 2, field)
+
+		dots, err := DotShares(sharesA, sharesB, triples)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		got := RecoverInt(field, dots...)
+		expected, _ := aBig.Dot(bBig)
+		if got.Cmp(expected) != 0 {
+			t.Fatalf("Incorrest result. Expected %v, got %v", expected, got)
+		}
+	}
+}
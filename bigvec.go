@@ -2,7 +2,9 @@ package vec
 
 import (
 	"crypto/rand"
+	"encoding/binary"
 	"errors"
+	"math"
 	"math/big"
 
 	"github.com/ncw/gmp"
@@ -37,6 +39,70 @@ func (v *Vec) ToBigVec(fpScaleFactor *gmp.Int) *BigVec {
 	return NewBigVec(vector)
 }
 
+// ErrFixedPointOverflow is returned when a decoded value is outside the range of a float64
+var ErrFixedPointOverflow = errors.New("fixed-point value overflows float64 range")
+
+// ErrFixedPointPrecision is returned when a non-zero value cannot be represented
+// as a normal float64 after decoding
+var ErrFixedPointPrecision = errors.New("fixed-point value loses precision when decoded")
+
+// DecodeFixedPoint returns the float x / fpScaleFactor^depth where depth
+// is the number of scale factors accumulated by x
+// (1 for a value encoded with ToBigVec, 2 after multiplying two such values, etc.)
+// rounded to the nearest float64
+func DecodeFixedPoint(x *gmp.Int, fpScaleFactor *gmp.Int, depth int) (float64, error) {
+
+	if depth < 0 {
+		return 0, errors.New("fixed-point depth must be non-negative")
+	}
+
+	if fpScaleFactor.Sign() <= 0 {
+		return 0, errors.New("fixed-point scale factor must be positive")
+	}
+
+	scale := new(gmp.Int).Exp(fpScaleFactor, gmp.NewInt(int64(depth)), nil)
+
+	xInt := new(big.Int).SetBytes(x.Bytes())
+	xInt.Mul(xInt, big.NewInt(int64(x.Sign())))
+
+	// the quotient is rounded once to the 53 bits of a float64 mantissa
+	e := new(big.Float).SetPrec(53)
+	e.Quo(new(big.Float).SetInt(xInt), new(big.Float).SetInt(new(big.Int).SetBytes(scale.Bytes())))
+	res, _ := e.Float64()
+
+	if math.IsInf(res, 0) {
+		return 0, ErrFixedPointOverflow
+	}
+
+	if x.Sign() != 0 && math.Abs(res) < 0x1p-1022 {
+		return 0, ErrFixedPointPrecision
+	}
+
+	return res, nil
+}
+
+// ToVec converts a BigVec with fixed-point encoding back to a vector
+// where depth is the number of scale factors accumulated by the coordinates
+func (a *BigVec) ToVec(fpScaleFactor *gmp.Int, depth int) (*Vec, error) {
+
+	vector := make([]float64, len(a.Coords))
+	for i, coord := range a.Coords {
+		value, err := DecodeFixedPoint(coord, fpScaleFactor, depth)
+		if err != nil {
+			return nil, err
+		}
+		vector[i] = value
+	}
+
+	return NewVec(vector), nil
+}
+
+// ToVecSigned converts a BigVec with fixed-point encoding in Z_n back to a vector
+// where all values > n/2 are treated as negative values
+func (a *BigVec) ToVecSigned(n *gmp.Int, fpScaleFactor *gmp.Int, depth int) (*Vec, error) {
+	return a.Clone().DecodeSignedValues(n).ToVec(fpScaleFactor, depth)
+}
+
 // NewBigZeroVec generates a new all-zero vector
 func NewBigZeroVec(dim int) *BigVec {
 
@@ -202,3 +268,70 @@ func (a *BigVec) Clone() *BigVec {
 
 	return NewBigVec(newCoords)
 }
+
+// MarshalBinary encodes the vector as the number of coordinates followed by
+// the sign, length and big-endian magnitude of each coordinate
+func (a *BigVec) MarshalBinary() ([]byte, error) {
+
+	buf := binary.BigEndian.AppendUint32(nil, uint32(len(a.Coords)))
+	for _, coord := range a.Coords {
+		mag := coord.Bytes()
+		sign := byte(0)
+		if coord.Sign() < 0 {
+			sign = 1
+		}
+
+		buf = append(buf, sign)
+		buf = binary.BigEndian.AppendUint32(buf, uint32(len(mag)))
+		buf = append(buf, mag...)
+	}
+
+	return buf, nil
+}
+
+// UnmarshalBinary decodes a vector encoded with MarshalBinary and sets a to the result
+func (a *BigVec) UnmarshalBinary(data []byte) error {
+
+	errMalformed := errors.New("malformed vector encoding")
+
+	if len(data) < 4 {
+		return errMalformed
+	}
+
+	dim := int(binary.BigEndian.Uint32(data))
+	data = data[4:]
+
+	// each coordinate takes at least 5 bytes
+	if dim > len(data)/5 {
+		return errMalformed
+	}
+
+	coords := make([]*gmp.Int, dim)
+	for i := 0; i < dim; i++ {
+		if len(data) < 5 {
+			return errMalformed
+		}
+
+		sign := data[0]
+		size := int(binary.BigEndian.Uint32(data[1:]))
+		data = data[5:]
+
+		if size > len(data) {
+			return errMalformed
+		}
+
+		coords[i] = new(gmp.Int).SetBytes(data[:size])
+		if sign == 1 {
+			coords[i].Neg(coords[i])
+		}
+		data = data[size:]
+	}
+
+	if len(data) != 0 {
+		return errMalformed
+	}
+
+	a.Coords = coords
+
+	return nil
+}
@@ -1,6 +1,7 @@
 package vec
 
 import (
+	"math/big"
 	"testing"
 
 	"github.com/ncw/gmp"
@@ -212,3 +213,116 @@ func TestBigVecEqual(t *testing.T) {
 		}
 	}
 }
+
+func TestToVec(t *testing.T) {
+
+	scale := gmp.NewInt(1 << 20)
+
+	for trial := 0; trial < 100; trial++ {
+		a := NewRandomVec(dim, -100, 100).Scale(0.5)
+		b := NewRandomVec(dim, -100, 100).Scale(0.25)
+
+		res, err := a.ToBigVec(scale).ToVec(scale, 1)
+		if err != nil || !res.Equal(a) {
+			t.Fatalf("Expected %v, got %v (err = %v)\n", a.Coords, res, err)
+		}
+
+		prod, _ := a.ToBigVec(scale).Mul(b.ToBigVec(scale))
+		res, err = prod.ToVec(scale, 2)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for i := 0; i < a.Size(); i++ {
+			if res.Coords[i] != a.Coords[i]*b.Coords[i] {
+				t.Fatalf("Expected %v, got %v\n", a.Coords[i]*b.Coords[i], res.Coords[i])
+			}
+		}
+	}
+}
+
+func TestDecodeFixedPointRounding(t *testing.T) {
+
+	// scales that are not powers of two decode to the nearest float64
+	if res, err := DecodeFixedPoint(gmp.NewInt(1234), gmp.NewInt(1000), 1); err != nil || res != 1.234 {
+		t.Fatalf("Expected 1.234, got %v (err = %v)\n", res, err)
+	}
+
+	if res, err := DecodeFixedPoint(gmp.NewInt(-1), gmp.NewInt(3), 1); err != nil || res != -1.0/3 {
+		t.Fatalf("Expected %v, got %v (err = %v)\n", -1.0/3, res, err)
+	}
+
+	// products at depth 2 have more significant bits than a float64
+	scale := gmp.NewInt(1 << 20)
+	prod, _ := NewVec([]float64{1000.123}).ToBigVec(scale).Mul(NewVec([]float64{999.7}).ToBigVec(scale))
+
+	x := new(big.Int).SetBytes(prod.Coords[0].Bytes())
+	expected, _ := new(big.Rat).SetFrac(x, new(big.Int).Lsh(big.NewInt(1), 40)).Float64()
+
+	if res, err := DecodeFixedPoint(prod.Coords[0], scale, 2); err != nil || res != expected {
+		t.Fatalf("Expected %v, got %v (err = %v)\n", expected, res, err)
+	}
+}
+
+func TestToVecSigned(t *testing.T) {
+
+	field := randomPrime(100)
+	scale := gmp.NewInt(1 << 10)
+
+	for trial := 0; trial < 100; trial++ {
+		a := NewRandomVec(dim, -100, 100).Scale(0.5)
+		aEncoded := a.ToBigVec(scale).Mod(field)
+
+		res, err := aEncoded.ToVecSigned(field, scale, 1)
+		if err != nil || !res.Equal(a) {
+			t.Fatalf("Expected %v, got %v (err = %v)\n", a.Coords, res, err)
+		}
+	}
+}
+
+func TestDecodeFixedPointErrors(t *testing.T) {
+
+	huge := new(gmp.Int).Lsh(gmp.NewInt(1), 2000)
+	if _, err := DecodeFixedPoint(huge, gmp.NewInt(2), 1); err != ErrFixedPointOverflow {
+		t.Fatalf("Expected overflow error, got %v\n", err)
+	}
+
+	scale := new(gmp.Int).Lsh(gmp.NewInt(1), 600)
+	if _, err := DecodeFixedPoint(gmp.NewInt(1), scale, 2); err != ErrFixedPointPrecision {
+		t.Fatalf("Expected precision error, got %v\n", err)
+	}
+
+	if res, err := DecodeFixedPoint(gmp.NewInt(0), scale, 2); err != nil || res != 0 {
+		t.Fatalf("Expected 0, got %v (err = %v)\n", res, err)
+	}
+
+	if _, err := DecodeFixedPoint(gmp.NewInt(1), gmp.NewInt(0), 1); err == nil {
+		t.Fatalf("Expected error for a zero scale factor\n")
+	}
+
+	if _, err := DecodeFixedPoint(gmp.NewInt(1), gmp.NewInt(-2), 1); err == nil {
+		t.Fatalf("Expected error for a negative scale factor\n")
+	}
+}
+
+func TestMarshalBinary(t *testing.T) {
+
+	for trial := 0; trial < 100; trial++ {
+		aBig := NewBigRandomVec(dim, gmp.NewInt(-1000000), gmp.NewInt(1000000))
+		aBig.Coords[0] = gmp.NewInt(0)
+
+		data, err := aBig.MarshalBinary()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		res := &BigVec{}
+		if err := res.UnmarshalBinary(data); err != nil || !res.Equal(aBig) {
+			t.Fatalf("Expected %v, got %v (err = %v)\n", aBig, res, err)
+		}
+
+		if err := res.UnmarshalBinary(data[:len(data)-1]); err == nil {
+			t.Fatalf("Expected error decoding truncated vector\n")
+		}
+	}
+}
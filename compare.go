@@ -0,0 +1,206 @@
+package vec
+
+import (
+	"errors"
+
+	"github.com/ncw/gmp"
+)
+
+// ComparisonTuple is one party's share of the preprocessing for comparing
+// secret shared vectors: a random r = r_low + 2^bits * r_high where r_low has bit length bits,
+// shares of the bits of r_low (an edaBit) and bits many multiplication triples
+type ComparisonTuple struct {
+	R       *ShareVec
+	Bits    []*ShareVec // shares of the bits of r_low (least significant first)
+	Triples []*BeaverTriple
+}
+
+// NewComparisonTuples returns additive shares of the preprocessing for comparing
+// vectors of the given dimension for each of the parties (trusted dealer)
+// where the compared values differ by less than 2^bits in absolute value
+func NewComparisonTuples(dim int, bits int, numParties int, p *gmp.Int) []*ComparisonTuple {
+
+	if bits+statisticalSecurity+3 > p.BitLen() {
+		panic("field is too small for comparing values of the bit length")
+	}
+
+	// r = sum_k 2^k * b_k + 2^bits * r_high where r_high statistically masks the top bit
+	bound := new(gmp.Int).Lsh(gmp.NewInt(1), statisticalSecurity)
+	r := NewBigZeroVec(dim)
+	for j := range r.Coords {
+		r.Coords[j].Lsh(newCryptoRandom(bound), uint(bits))
+	}
+
+	tuples := make([]*ComparisonTuple, numParties)
+	for i := range tuples {
+		tuples[i] = &ComparisonTuple{
+			Bits:    make([]*ShareVec, bits),
+			Triples: make([]*BeaverTriple, bits),
+		}
+	}
+
+	for k := 0; k < bits; k++ {
+		b := NewBigRandomVec(dim, gmp.NewInt(0), gmp.NewInt(1))
+		for j, coord := range b.Coords {
+			r.Coords[j].Add(r.Coords[j], new(gmp.Int).Lsh(coord, uint(k)))
+		}
+
+		for i, share := range SecretShare(b, numParties, p) {
+			tuples[i].Bits[k] = share
+		}
+
+		for i, triple := range NewBeaverTriples(dim, numParties, p) {
+			tuples[i].Triples[k] = triple
+		}
+	}
+
+	for i, share := range SecretShare(r.Mod(p), numParties, p) {
+		tuples[i].R = share
+	}
+
+	return tuples
+}
+
+// LessThan returns the party's share of the component-wise comparison bit [x < y]
+// where the values of x and y differ by less than 2^bits in absolute value
+func (p *Party) LessThan(x *ShareVec, y *ShareVec, bits int, tuple *ComparisonTuple) (*ShareVec, error) {
+
+	if x.Index != y.Index {
+		return nil, errors.New("Index of share a != index of share b")
+	}
+
+	z, err := x.Vec.Clone().Sub(y.Vec)
+	if err != nil {
+		return nil, err
+	}
+
+	return p.lessThanZero(&ShareVec{z.Mod(x.P), x.P, x.Index}, bits, tuple)
+}
+
+// LessThanPublic returns the party's share of the component-wise comparison bit [x < t]
+// for the additively shared x and the public vector t where the values of x and t differ by less than 2^bits in absolute value
+func (p *Party) LessThanPublic(x *ShareVec, t *BigVec, bits int, tuple *ComparisonTuple) (*ShareVec, error) {
+
+	z, err := x.SubPublic(t)
+	if err != nil {
+		return nil, err
+	}
+
+	return p.lessThanZero(z, bits, tuple)
+}
+
+// lessThanZero returns the party's share of [z < 0] for |z| < 2^bits by opening
+// c = z + 2^bits + r and computing the top bit of a = z + 2^bits from
+// a mod 2^bits = (c mod 2^bits) - r_low + 2^bits * [c mod 2^bits < r_low]
+func (p *Party) lessThanZero(z *ShareVec, bits int, tuple *ComparisonTuple) (*ShareVec, error) {
+
+	if z.Index != p.ID || tuple.R.Index != p.ID {
+		return nil, errors.New("index of share does not match party ID")
+	}
+
+	if bits < 1 {
+		return nil, errors.New("bit length must be positive")
+	}
+
+	if len(tuple.Bits) != bits || len(tuple.Triples) != bits || tuple.R.Vec.Size() != z.Vec.Size() {
+		return nil, errors.New("comparison tuple does not match the bit length and size of the vector")
+	}
+
+	if bits+statisticalSecurity+3 > z.P.BitLen() {
+		return nil, errors.New("field is too small for comparing values of the bit length")
+	}
+
+	field := z.P
+	pow := new(gmp.Int).Lsh(gmp.NewInt(1), uint(bits))
+
+	// share of a = z + 2^bits
+	a := z.Vec.Clone()
+	if p.ID == 0 {
+		for _, coord := range a.Coords {
+			coord.Add(coord, pow)
+		}
+	}
+
+	c, err := a.Clone().Add(tuple.R.Vec)
+	if err != nil {
+		return nil, err
+	}
+
+	// c < p so its opening in [0, p) is c itself
+	opened, err := p.Open(&ShareVec{c.Mod(field), field, p.ID})
+	if err != nil {
+		return nil, err
+	}
+
+	low := opened.Clone()
+	for _, coord := range low.Coords {
+		coord.Mod(coord, pow)
+	}
+
+	u, err := p.bitwiseLessThan(low, tuple.Bits, tuple.Triples)
+	if err != nil {
+		return nil, err
+	}
+
+	// share of a mod 2^bits = low - r_low + 2^bits * u
+	rLow := NewBigZeroVec(z.Vec.Size())
+	for k, bit := range tuple.Bits {
+		for j, coord := range bit.Vec.Coords {
+			rLow.Coords[j].Add(rLow.Coords[j], new(gmp.Int).Lsh(coord, uint(k)))
+		}
+	}
+
+	powInv := new(gmp.Int).ModInverse(pow, field)
+
+	res := NewBigZeroVec(z.Vec.Size())
+	for j := range res.Coords {
+		aLow := new(gmp.Int).Mul(pow, u.Vec.Coords[j])
+		aLow.Sub(aLow, rLow.Coords[j])
+		if p.ID == 0 {
+			aLow.Add(aLow, low.Coords[j])
+		}
+
+		// top bit of a is (a - a mod 2^bits) / 2^bits and [z < 0] = 1 - top bit
+		top := new(gmp.Int).Sub(a.Coords[j], aLow)
+		top.Mul(top, powInv)
+
+		res.Coords[j].Neg(top)
+		if p.ID == 0 {
+			res.Coords[j].Add(res.Coords[j], gmp.NewInt(1))
+		}
+	}
+
+	return &ShareVec{res.Mod(field), field, p.ID}, nil
+}
+
+// bitwiseLessThan returns the party's share of the component-wise comparison bit [c < r]
+// for the public vector c and the vector r shared bitwise (least significant bit first)
+func (p *Party) bitwiseLessThan(c *BigVec, bits []*ShareVec, triples []*BeaverTriple) (*ShareVec, error) {
+
+	field := bits[0].P
+	lt := &ShareVec{NewBigZeroVec(c.Size()), field, p.ID}
+
+	// scan from the least significant bit where the comparison of the
+	// lowest k+1 bits is r_k if c_k != r_k and the comparison of the lowest k bits otherwise:
+	// lt = r_k * lt if c_k = 1 and lt = r_k + lt - r_k * lt if c_k = 0
+	for k, bit := range bits {
+		prod, err := p.Mul(bit, lt, triples[k])
+		if err != nil {
+			return nil, err
+		}
+
+		next := NewBigZeroVec(c.Size())
+		for j, coord := range c.Coords {
+			if coord.Bit(k) == 1 {
+				next.Coords[j].Set(prod.Vec.Coords[j])
+			} else {
+				next.Coords[j].Add(bit.Vec.Coords[j], lt.Vec.Coords[j])
+				next.Coords[j].Sub(next.Coords[j], prod.Vec.Coords[j])
+			}
+		}
+
+		lt = &ShareVec{next.Mod(field), field, p.ID}
+	}
+
+	return lt, nil
+}
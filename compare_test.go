@@ -0,0 +1,89 @@
+package vec
+
+import (
+	"testing"
+
+	"github.com/ncw/gmp"
+)
+
+func TestPartyLessThan(t *testing.T) {
+
+	field := randomPrime(128)
+	numParties := 3
+	bits := 32
+
+	for _, transports := range [][]Transport{memoryTransports(numParties), tcpTransports(t, numParties)} {
+		for trial := 0; trial < 5; trial++ {
+
+			aBig := NewBigRandomVec(dim, gmp.NewInt(-(1 << 30)), gmp.NewInt(1<<30))
+			bBig := NewBigRandomVec(dim, gmp.NewInt(-(1 << 30)), gmp.NewInt(1<<30))
+
+			// include equal and adjacent values
+			bBig.Coords[0].Set(aBig.Coords[0])
+			bBig.Coords[1].Add(aBig.Coords[1], gmp.NewInt(1))
+			bBig.Coords[2].Sub(aBig.Coords[2], gmp.NewInt(1))
+
+			sharesA := SecretShare(aBig, numParties, field)
+			sharesB := SecretShare(bBig, numParties, field)
+			tuples := NewComparisonTuples(dim, bits, numParties, field)
+
+			res := make([]*ShareVec, numParties)
+			runParties(t, transports, func(party *Party) error {
+				var err error
+				res[party.ID], err = party.LessThan(sharesA[party.ID], sharesB[party.ID], bits, tuples[party.ID])
+				return err
+			})
+
+			lt, err := RecoverVector(res...)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			for i := range aBig.Coords {
+				expected := int64(0)
+				if aBig.Coords[i].Cmp(bBig.Coords[i]) < 0 {
+					expected = 1
+				}
+
+				if lt.Coords[i].Int64() != expected {
+					t.Fatalf("Incorrest result for %v < %v. Expected %v, got %v", aBig.Coords[i], bBig.Coords[i], expected, lt.Coords[i])
+				}
+			}
+		}
+	}
+}
+
+func TestPartyLessThanPublic(t *testing.T) {
+
+	field := randomPrime(128)
+	numParties := 3
+	bits := 16
+
+	aBig := NewBigRandomVec(dim, gmp.NewInt(-1000), gmp.NewInt(1000))
+	threshold := NewBigRandomVec(dim, gmp.NewInt(-1000), gmp.NewInt(1000))
+	shares := SecretShare(aBig, numParties, field)
+	tuples := NewComparisonTuples(dim, bits, numParties, field)
+
+	res := make([]*ShareVec, numParties)
+	runParties(t, memoryTransports(numParties), func(party *Party) error {
+		var err error
+		res[party.ID], err = party.LessThanPublic(shares[party.ID], threshold, bits, tuples[party.ID])
+		return err
+	})
+
+	lt, err := RecoverVector(res...)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := range aBig.Coords {
+		expected := int64(0)
+		if aBig.Coords[i].Cmp(threshold.Coords[i]) < 0 {
+			expected = 1
+		}
+
+		if lt.Coords[i].Int64() != expected {
+			t.Fatalf("Incorrest result for %v < %v. Expected %v, got %v", aBig.Coords[i], threshold.Coords[i], expected, lt.Coords[i])
+		}
+	}
+}
@@ -0,0 +1,199 @@
+package vec
+
+import (
+	"errors"
+
+	"github.com/ncw/gmp"
+	"github.com/sachaservan/paillier"
+)
+
+// statisticalSecurity is the number of bits by which masks exceed the masked
+// values so that masked values statistically hide the original values
+const statisticalSecurity = 40
+
+// MaskToShare is the first step of converting an encrypted vector to additive
+// secret shares mod p, run by the holder of the encrypted vector.
+// Every coordinate x (with |x| < 2^bits) is masked homomorphically with a random
+// r in [0, 2^(bits+40)) so that the masked value x + 2^bits + r never wraps
+// around the plaintext modulus and statistically hides x.
+// Returns the masked vector to send to the key holder and the holder's share (with Index 0)
+func MaskToShare(a *EncryptedVec, p *gmp.Int, bits int) (*EncryptedVec, *ShareVec, error) {
+
+	if err := checkConversion(a.Scheme.PlaintextModulus(), p, bits); err != nil {
+		return nil, nil, err
+	}
+
+	offset := new(gmp.Int).Lsh(gmp.NewInt(1), uint(bits))
+	bound := new(gmp.Int).Lsh(gmp.NewInt(1), uint(bits+statisticalSecurity))
+
+	mask := NewBigZeroVec(a.Size())
+	share := NewBigZeroVec(a.Size())
+	for i := range mask.Coords {
+		r := newCryptoRandom(bound)
+		mask.Coords[i].Add(r, offset)
+
+		// the holder's share is -(r + 2^bits) mod p
+		share.Coords[i].Neg(mask.Coords[i])
+	}
+
+	masked, err := a.AddPlain(mask)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return masked, &ShareVec{share.Mod(p), p, 0}, nil
+}
+
+// DecryptToShare is the second step of converting an encrypted vector to additive
+// secret shares mod p, run by the Paillier key holder on the masked vector.
+// Returns the key holder's share (with Index 1)
+func DecryptToShare(masked *EncryptedVec, sk *paillier.SecretKey, p *gmp.Int) (*ShareVec, error) {
+	return DecryptToShareWith(masked, NewPaillierHE(&sk.PublicKey, sk), p)
+}
+
+// DecryptToShareWith is DecryptToShare for a vector masked under the scheme
+// where the scheme must know the secret key
+func DecryptToShareWith(masked *EncryptedVec, scheme AdditiveHE, p *gmp.Int) (*ShareVec, error) {
+
+	// the masked values are non-negative and smaller than the plaintext
+	// modulus, so their decryption is exact and can be reduced mod p
+	res, err := DecryptWith(masked, scheme)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ShareVec{res.Mod(p), p, 1}, nil
+}
+
+// EncryptedToShares converts the Paillier encrypted vector with coordinates
+// |x| < 2^bits into two additive secret shares mod p (run in-process)
+func EncryptedToShares(a *EncryptedVec, sk *paillier.SecretKey, p *gmp.Int, bits int) ([]*ShareVec, error) {
+
+	masked, holderShare, err := MaskToShare(a, p, bits)
+	if err != nil {
+		return nil, err
+	}
+
+	keyShare, err := DecryptToShare(masked, sk, p)
+	if err != nil {
+		return nil, err
+	}
+
+	return []*ShareVec{holderShare, keyShare}, nil
+}
+
+// checkConversion returns an error if values with absolute value < 2^bits
+// cannot be converted between plaintexts mod n and shares mod p
+func checkConversion(n *gmp.Int, p *gmp.Int, bits int) error {
+
+	if bits < 0 {
+		return errors.New("bit length of the values must be non-negative")
+	}
+
+	// run 20 tests of Rabin-Miller
+	if !p.ProbablyPrime(20) {
+		return errors.New("trying to secret share in a non-prime order field")
+	}
+
+	// signed values must be uniquely represented mod p
+	if bits+1 >= p.BitLen() {
+		return errors.New("field is too small for the bit length of the values")
+	}
+
+	// masked values must not wrap around the plaintext modulus
+	if bits+statisticalSecurity+2 >= n.BitLen() {
+		return errors.New("plaintext modulus is too small for the bit length of the values")
+	}
+
+	return nil
+}
+
+// EncryptShare returns a Paillier encryption of the party's share
+// to be combined with the encrypted shares of the other parties
+func EncryptShare(a *ShareVec, pk *paillier.PublicKey) *EncryptedVec {
+	return EncryptShareWith(a, NewPaillierHE(pk, nil))
+}
+
+// EncryptShareWith returns an encryption of the party's share under the scheme
+func EncryptShareWith(a *ShareVec, scheme AdditiveHE) *EncryptedVec {
+	return EncryptWith(a.Vec.Clone().Mod(a.P), scheme)
+}
+
+// EncryptedShareSum is the homomorphic sum of encrypted additive shares mod P.
+// The plaintext of Vec is x + k*P for the shared vector x and some 0 <= k < number of shares,
+// so it must be decrypted with DecryptShared, which reduces it mod P
+type EncryptedShareSum struct {
+	Vec *EncryptedVec
+	P   *gmp.Int
+}
+
+// NewEncryptedShareSum returns the sum of encrypted shares mod p with the encrypted
+// vector vec (e.g. to rebuild a sum stored with its field after rerandomizing it)
+func NewEncryptedShareSum(vec *EncryptedVec, p *gmp.Int) *EncryptedShareSum {
+	return &EncryptedShareSum{
+		Vec: vec,
+		P:   p,
+	}
+}
+
+// CombineEncryptedShares returns the homomorphic sum of the encrypted additive shares mod p
+// to be decrypted with DecryptShared
+// throws an error if there are no shares or the sum of the shares could wrap around the plaintext modulus
+func CombineEncryptedShares(p *gmp.Int, shares ...*EncryptedVec) (*EncryptedShareSum, error) {
+
+	if len(shares) == 0 {
+		return nil, errors.New("no shares to combine")
+	}
+
+	// the sum of the shares is smaller than len(shares) * p
+	bound := new(gmp.Int).Mul(p, gmp.NewInt(int64(len(shares))))
+	if bound.Cmp(shares[0].Scheme.PlaintextModulus()) >= 0 {
+		return nil, errors.New("plaintext modulus is too small for the sum of the shares")
+	}
+
+	res := shares[0]
+	for _, share := range shares[1:] {
+		var err error
+		if res, err = res.Add(share); err != nil {
+			return nil, err
+		}
+	}
+
+	return NewEncryptedShareSum(res, p), nil
+}
+
+// DecryptShared returns the (signed) vector encrypted in the combined encrypted
+// shares mod P (see CombineEncryptedShares)
+// throws ErrSchemeMismatch if the sum is not encrypted under the public key of sk
+func DecryptShared(a *EncryptedShareSum, sk *paillier.SecretKey) (*BigVec, error) {
+	return DecryptSharedWith(a, NewPaillierHE(&sk.PublicKey, sk))
+}
+
+// DecryptSharedWith is DecryptShared for combined shares encrypted under the scheme
+// where the scheme must know the secret key
+func DecryptSharedWith(a *EncryptedShareSum, scheme AdditiveHE) (*BigVec, error) {
+
+	res, err := DecryptWith(a.Vec, scheme)
+	if err != nil {
+		return nil, err
+	}
+
+	return res.Mod(a.P).DecodeSignedValues(a.P), nil
+}
+
+// SharesToEncrypted converts the additive secret shares into a Paillier
+// encryption of the shared vector (run in-process) to be decrypted with DecryptShared
+// throws an error if there are no shares
+func SharesToEncrypted(shares []*ShareVec, pk *paillier.PublicKey) (*EncryptedShareSum, error) {
+
+	if len(shares) == 0 {
+		return nil, errors.New("no shares to combine")
+	}
+
+	encrypted := make([]*EncryptedVec, len(shares))
+	for i, share := range shares {
+		encrypted[i] = EncryptShare(share, pk)
+	}
+
+	return CombineEncryptedShares(shares[0].P, encrypted...)
+}
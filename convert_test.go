@@ -0,0 +1,181 @@
+package vec
+
+import (
+	"testing"
+
+	"github.com/ncw/gmp"
+	"github.com/sachaservan/paillier"
+)
+
+func TestEncryptedToShares(t *testing.T) {
+
+	pk, sk := paillier.KeyGen(keyBits)
+
+	for trial := 0; trial < 10; trial++ {
+
+		// fields both much smaller and larger than the Paillier modulus
+		for _, p := range []*gmp.Int{randomPrime(64), randomPrime(keyBits + 128)} {
+
+			aBig := NewBigRandomVec(dim, gmp.NewInt(-1000000), gmp.NewInt(1000000))
+			encA := Encrypt(aBig, pk)
+
+			shares, err := EncryptedToShares(encA, sk, p, 20)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if shares[0].Index != 0 || shares[1].Index != 1 {
+				t.Fatalf("Incorrect share indices %v and %v", shares[0].Index, shares[1].Index)
+			}
+
+			res, err := RecoverVector(shares...)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !res.Equal(aBig) {
+				t.Fatalf("Incorrest result. \nExpected %v \nGot %v", aBig, res)
+			}
+		}
+	}
+}
+
+func TestEncryptedToSharesHomomorphic(t *testing.T) {
+
+	pk, sk := paillier.KeyGen(keyBits)
+	p := randomPrime(64)
+
+	aBig := NewBigRandomVec(dim, gmp.NewInt(-1000), gmp.NewInt(1000))
+	bBig := NewBigRandomVec(dim, gmp.NewInt(-1000), gmp.NewInt(1000))
+
+	dot, err := Encrypt(aBig, pk).Dot(bBig)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	masked, holderShare, err := MaskToShare(NewEncryptedVec(NewPaillierHE(pk, nil), []Ciphertext{dot}), p, 30)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	keyShare, err := DecryptToShare(masked, sk, p)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	res, err := RecoverVector(holderShare, keyShare)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected, _ := aBig.Dot(bBig)
+	if res.Coord(0).Cmp(expected) != 0 {
+		t.Fatalf("Incorrest result. Expected %v, got %v", expected, res.Coord(0))
+	}
+}
+
+func TestEncryptedToSharesBounds(t *testing.T) {
+
+	pk, sk := paillier.KeyGen(keyBits)
+	encA := Encrypt(NewBigRandomVec(dim, gmp.NewInt(-1000), gmp.NewInt(1000)), pk)
+
+	if _, err := EncryptedToShares(encA, sk, randomPrime(20), 20); err == nil {
+		t.Fatalf("Expected an error for a field too small for the values")
+	}
+
+	if _, err := EncryptedToShares(encA, sk, randomPrime(keyBits), keyBits-statisticalSecurity); err == nil {
+		t.Fatalf("Expected an error for values too large for the plaintext modulus")
+	}
+
+	if _, err := EncryptedToShares(encA, sk, gmp.NewInt(1<<20), 10); err == nil {
+		t.Fatalf("Expected an error for a non-prime field")
+	}
+}
+
+func TestSharesToEncrypted(t *testing.T) {
+
+	pk, sk := paillier.KeyGen(keyBits)
+
+	for trial := 0; trial < 10; trial++ {
+
+		p := randomPrime(128)
+		aBig := NewBigRandomVec(dim, gmp.NewInt(-1000000), gmp.NewInt(1000000))
+		shares := SecretShare(aBig, 5, p)
+
+		encA, err := SharesToEncrypted(shares, pk)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		res, err := DecryptShared(encA, sk)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !res.Equal(aBig) {
+			t.Fatalf("Incorrest result. \nExpected %v \nGot %v", aBig, res)
+		}
+
+		// a stored sum can be rebuilt from its (rerandomized) encrypted vector and field
+		res, err = DecryptShared(NewEncryptedShareSum(encA.Vec.Rerandomize(), new(gmp.Int).Set(p)), sk)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !res.Equal(aBig) {
+			t.Fatalf("Incorrest result. \nExpected %v \nGot %v", aBig, res)
+		}
+	}
+
+	if _, err := SharesToEncrypted(nil, pk); err == nil {
+		t.Fatalf("Expected an error for no shares")
+	}
+}
+
+func TestSharesToEncryptedRoundTrip(t *testing.T) {
+
+	pk, sk := paillier.KeyGen(keyBits)
+	p := randomPrime(64)
+
+	aBig := NewBigRandomVec(dim, gmp.NewInt(-1000), gmp.NewInt(1000))
+	bBig := NewBigRandomVec(dim, gmp.NewInt(-1000), gmp.NewInt(1000))
+
+	// compute on shares and store the result in encrypted form
+	sharesA := SecretShare(aBig, 3, p)
+	sharesB := SecretShare(bBig, 3, p)
+
+	encrypted := make([]*EncryptedVec, len(sharesA))
+	for i := range sharesA {
+		sum, err := sharesA[i].Add(sharesB[i])
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		encrypted[i] = EncryptShare(sum, pk)
+	}
+
+	encSum, err := CombineEncryptedShares(p, encrypted...)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := aBig.Clone()
+	expected.Add(bBig)
+
+	res, err := DecryptSharedWith(encSum, NewPaillierHE(pk, sk))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !res.Equal(expected) {
+		t.Fatalf("Incorrest result. \nExpected %v \nGot %v", expected, res)
+	}
+
+	if _, err := CombineEncryptedShares(randomPrime(keyBits), encrypted...); err == nil {
+		t.Fatalf("Expected an error for a field too large for the plaintext modulus")
+	}
+
+	if _, err := CombineEncryptedShares(p); err == nil {
+		t.Fatalf("Expected an error for no shares")
+	}
+}
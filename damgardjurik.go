@@ -0,0 +1,238 @@
+package vec
+
+import (
+	"crypto/rand"
+	"errors"
+
+	"github.com/ncw/gmp"
+)
+
+// DamgardJurikCiphertext is a Damgård–Jurik ciphertext in Z_{n^(s+1)}
+type DamgardJurikCiphertext struct {
+	C *gmp.Int
+}
+
+// DamgardJurikHE is the Damgård–Jurik generalisation of Paillier as an AdditiveHE scheme
+// with plaintexts in Z_{n^s}, so that larger values (e.g. high-precision fixed-point dot products
+// or long sums) can be encrypted without wrapping around the plaintext modulus.
+// With S = 1 the scheme is the Paillier cryptosystem
+type DamgardJurikHE struct {
+	N      *gmp.Int
+	S      int
+	Lambda *gmp.Int // lcm(p-1, q-1); nil if only the public key is known
+
+	ns  *gmp.Int // n^s
+	ns1 *gmp.Int // n^(s+1)
+}
+
+// NewDamgardJurikHE generates a new Damgård–Jurik key with a modulus
+// n of the given bit length and plaintext space Z_{n^s}
+func NewDamgardJurikHE(bits int, s int) (*DamgardJurikHE, error) {
+
+	if s < 1 {
+		return nil, errors.New("Damgård–Jurik parameter s must be at least 1")
+	}
+
+	one := gmp.NewInt(1)
+
+	for {
+		p, err := rand.Prime(rand.Reader, bits/2)
+		if err != nil {
+			return nil, err
+		}
+
+		q, err := rand.Prime(rand.Reader, bits-bits/2)
+		if err != nil {
+			return nil, err
+		}
+
+		pGmp := new(gmp.Int).SetBytes(p.Bytes())
+		qGmp := new(gmp.Int).SetBytes(q.Bytes())
+		if pGmp.Cmp(qGmp) == 0 {
+			continue
+		}
+
+		n := new(gmp.Int).Mul(pGmp, qGmp)
+		pm := new(gmp.Int).Sub(pGmp, one)
+		qm := new(gmp.Int).Sub(qGmp, one)
+		phi := new(gmp.Int).Mul(pm, qm)
+
+		// gcd(n, phi(n)) = 1 is required for decryption
+		if new(gmp.Int).GCD(nil, nil, n, phi).Cmp(one) != 0 {
+			continue
+		}
+
+		lambda := phi.Quo(phi, new(gmp.Int).GCD(nil, nil, pm, qm))
+
+		return newDamgardJurikHE(n, s, lambda), nil
+	}
+}
+
+// newDamgardJurikHE returns the scheme with precomputed powers of n
+func newDamgardJurikHE(n *gmp.Int, s int, lambda *gmp.Int) *DamgardJurikHE {
+
+	ns := new(gmp.Int).Exp(n, gmp.NewInt(int64(s)), nil)
+
+	return &DamgardJurikHE{
+		N:      n,
+		S:      s,
+		Lambda: lambda,
+		ns:     ns,
+		ns1:    new(gmp.Int).Mul(ns, n),
+	}
+}
+
+// PublicKey returns the scheme without the secret key
+func (s *DamgardJurikHE) PublicKey() *DamgardJurikHE {
+	return newDamgardJurikHE(s.N, s.S, nil)
+}
+
+// Encrypt returns an encryption (1 + n)^m * r^(n^s) mod n^(s+1) of m mod n^s
+func (s *DamgardJurikHE) Encrypt(m *gmp.Int) Ciphertext {
+
+	one := gmp.NewInt(1)
+	gcd := new(gmp.Int)
+
+	var r *gmp.Int
+	for {
+		r = newCryptoRandom(s.N)
+		if r.Sign() > 0 && gcd.GCD(nil, nil, r, s.N).Cmp(one) == 0 {
+			break
+		}
+	}
+
+	g := new(gmp.Int).Add(s.N, one)
+	c := new(gmp.Int).Exp(g, new(gmp.Int).Mod(m, s.ns), s.ns1)
+	c.Mul(c, r.Exp(r, s.ns, s.ns1))
+	c.Mod(c, s.ns1)
+
+	return &DamgardJurikCiphertext{c}
+}
+
+// EncryptZero returns a fresh encryption of zero
+func (s *DamgardJurikHE) EncryptZero() Ciphertext {
+	return s.Encrypt(gmp.NewInt(0))
+}
+
+// Add returns an encryption of the sum of the plaintexts of a and b
+func (s *DamgardJurikHE) Add(a, b Ciphertext) Ciphertext {
+	c := new(gmp.Int).Mul(mustDamgardJurikCiphertext(a).C, mustDamgardJurikCiphertext(b).C)
+	return &DamgardJurikCiphertext{c.Mod(c, s.ns1)}
+}
+
+// Sub returns an encryption of the difference of the plaintexts of a and b
+func (s *DamgardJurikHE) Sub(a, b Ciphertext) Ciphertext {
+	inv := new(gmp.Int).ModInverse(mustDamgardJurikCiphertext(b).C, s.ns1)
+	c := inv.Mul(mustDamgardJurikCiphertext(a).C, inv)
+	return &DamgardJurikCiphertext{c.Mod(c, s.ns1)}
+}
+
+// ConstMult returns an encryption of the plaintext of a times the constant k
+// where k is first reduced modulo n^s
+func (s *DamgardJurikHE) ConstMult(a Ciphertext, k *gmp.Int) Ciphertext {
+	c := new(gmp.Int).Exp(mustDamgardJurikCiphertext(a).C, new(gmp.Int).Mod(k, s.ns), s.ns1)
+	return &DamgardJurikCiphertext{c}
+}
+
+// Decrypt returns the plaintext of ct in Z_{n^s}
+func (s *DamgardJurikHE) Decrypt(ct Ciphertext) (*gmp.Int, error) {
+
+	if s.Lambda == nil {
+		return nil, ErrNoSecretKey
+	}
+
+	c, err := damgardJurikCiphertext(ct)
+	if err != nil {
+		return nil, err
+	}
+
+	// c^lambda = (1 + n)^(m*lambda mod n^s) mod n^(s+1)
+	a := new(gmp.Int).Exp(c.C, s.Lambda, s.ns1)
+	mLambda := s.log(a)
+
+	lambdaInv := new(gmp.Int).ModInverse(s.Lambda, s.ns)
+	m := mLambda.Mul(mLambda, lambdaInv)
+
+	return m.Mod(m, s.ns), nil
+}
+
+// PlaintextModulus returns the plaintext modulus n^s
+func (s *DamgardJurikHE) PlaintextModulus() *gmp.Int {
+	return s.ns
+}
+
+// CheckCiphertext returns ErrCiphertextType if ct is not a Damgård–Jurik ciphertext
+func (s *DamgardJurikHE) CheckCiphertext(ct Ciphertext) error {
+	_, err := damgardJurikCiphertext(ct)
+	return err
+}
+
+// Equal returns true if other is the Damgård–Jurik scheme with the same modulus and s
+func (s *DamgardJurikHE) Equal(other AdditiveHE) bool {
+	o, ok := other.(*DamgardJurikHE)
+	return ok && s.N.Cmp(o.N) == 0 && s.S == o.S
+}
+
+// log returns i in Z_{n^s} such that a = (1 + n)^i mod n^(s+1)
+// using the recursive algorithm of Damgård and Jurik
+func (s *DamgardJurikHE) log(a *gmp.Int) *gmp.Int {
+
+	i := gmp.NewInt(0)
+	nj := new(gmp.Int).Set(s.N)       // n^j
+	nj1 := new(gmp.Int).Mul(s.N, s.N) // n^(j+1)
+
+	for j := 1; j <= s.S; j++ {
+
+		// t1 = L(a mod n^(j+1)) = ((a mod n^(j+1)) - 1) / n
+		t1 := new(gmp.Int).Mod(a, nj1)
+		t1.Sub(t1, gmp.NewInt(1))
+		t1.Quo(t1, s.N)
+
+		t2 := new(gmp.Int).Set(i)
+		nk := gmp.NewInt(1)    // n^(k-1)
+		kFact := gmp.NewInt(1) // k!
+		for k := 2; k <= j; k++ {
+			i.Sub(i, gmp.NewInt(1))
+			t2.Mul(t2, i)
+			t2.Mod(t2, nj)
+
+			nk.Mul(nk, s.N)
+			kFact.Mul(kFact, gmp.NewInt(int64(k)))
+
+			term := new(gmp.Int).Mul(t2, nk)
+			term.Mul(term, new(gmp.Int).ModInverse(kFact, nj))
+			t1.Sub(t1, term)
+			t1.Mod(t1, nj)
+		}
+
+		i.Set(t1)
+
+		nj.Mul(nj, s.N)
+		nj1.Mul(nj1, s.N)
+	}
+
+	return i
+}
+
+// damgardJurikCiphertext returns ct as a Damgård–Jurik ciphertext
+// throws ErrCiphertextType if ct is not a Damgård–Jurik ciphertext
+func damgardJurikCiphertext(ct Ciphertext) (*DamgardJurikCiphertext, error) {
+
+	c, ok := ct.(*DamgardJurikCiphertext)
+	if !ok || c == nil || c.C == nil {
+		return nil, ErrCiphertextType
+	}
+
+	return c, nil
+}
+
+// mustDamgardJurikCiphertext is damgardJurikCiphertext for operations that cannot return an error
+func mustDamgardJurikCiphertext(ct Ciphertext) *DamgardJurikCiphertext {
+
+	c, err := damgardJurikCiphertext(ct)
+	if err != nil {
+		panic("trying to use a non-Damgård–Jurik ciphertext with the Damgård–Jurik scheme")
+	}
+
+	return c
+}
@@ -0,0 +1,159 @@
+package vec
+
+import (
+	"testing"
+
+	"github.com/ncw/gmp"
+)
+
+func TestDamgardJurikEncryptDecrypt(t *testing.T) {
+
+	for s := 1; s <= 3; s++ {
+
+		scheme, err := NewDamgardJurikHE(keyBits, s)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		ns := scheme.PlaintextModulus()
+		if ns.BitLen() < (keyBits-1)*s {
+			t.Fatalf("Plaintext modulus too small. Expected about %v bits, got %v", keyBits*s, ns.BitLen())
+		}
+
+		for trial := 0; trial < 10; trial++ {
+			m := newCryptoRandom(ns)
+
+			res, err := scheme.Decrypt(scheme.Encrypt(m))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if res.Cmp(m) != 0 {
+				t.Fatalf("Incorrest result. Expected %v, got %v", m, res)
+			}
+		}
+	}
+
+	scheme, err := NewDamgardJurikHE(keyBits, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := scheme.PublicKey().Decrypt(scheme.Encrypt(gmp.NewInt(1))); err != ErrNoSecretKey {
+		t.Fatalf("Expected ErrNoSecretKey, got %v", err)
+	}
+
+	if _, err := NewDamgardJurikHE(keyBits, 0); err == nil {
+		t.Fatalf("Expected an error for s = 0")
+	}
+}
+
+func TestDamgardJurikEncryptedVec(t *testing.T) {
+
+	scheme, err := NewDamgardJurikHE(keyBits, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	public := scheme.PublicKey()
+
+	// values larger than n that would wrap around under Paillier
+	max := new(gmp.Int).Set(scheme.N)
+	min := new(gmp.Int).Neg(max)
+
+	for trial := 0; trial < 5; trial++ {
+
+		aBig := NewBigRandomVec(dim, min, max)
+		bBig := NewBigRandomVec(dim, gmp.NewInt(-100), gmp.NewInt(100))
+		encA := EncryptWith(aBig, public)
+
+		sum, err := encA.Add(EncryptWith(bBig, public))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		res, err := DecryptWith(sum, scheme)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		expected := aBig.Clone()
+		expected.Add(bBig)
+
+		if !res.DecodeSignedValues(scheme.PlaintextModulus()).Equal(expected) {
+			t.Fatalf("Incorrest result. Expected %v, got %v", expected, res)
+		}
+
+		dot, err := EncryptWith(bBig, public).Dot(aBig)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		resDot, err := scheme.Decrypt(dot)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		expectedDot, _ := aBig.Dot(bBig)
+		if got := RecoverInt(scheme.PlaintextModulus(), resDot); got.Cmp(expectedDot) != 0 {
+			t.Fatalf("Incorrest result. Expected %v, got %v", expectedDot, got)
+		}
+	}
+}
+
+func TestDamgardJurikFixedPointDot(t *testing.T) {
+
+	scheme, err := NewDamgardJurikHE(keyBits, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// precision too high for the Paillier modulus at depth 2
+	fp, err := NewFixedPoint(240, 40, scheme.PlaintextModulus())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !(fp.Bits(2, dim) >= keyBits-1) {
+		t.Fatalf("Test precision does not exceed the Paillier modulus")
+	}
+
+	if err := fp.Check(2, dim); err != nil {
+		t.Fatal(err)
+	}
+
+	for trial := 0; trial < 5; trial++ {
+
+		a := NewRandomVec(dim, -1000, 1000)
+		b := NewRandomVec(dim, -1000, 1000)
+
+		encA, err := fp.EncryptWith(a, scheme.PublicKey())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		encodedB, err := fp.Encode(b)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		dot, err := encA.Dot(encodedB.Vec)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		res, err := fp.DecryptWith(NewEncryptVecWithCoords(scheme, []Ciphertext{dot}), scheme, 2, dim)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		expected := 0.0
+		for i := range a.Coords {
+			expected += a.Coords[i] * b.Coords[i]
+		}
+
+		if diff := res.Coords[0] - expected; diff > 1e-6 || diff < -1e-6 {
+			t.Fatalf("Incorrest result. Expected %v, got %v", expected, res.Coords[0])
+		}
+	}
+}
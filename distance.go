@@ -0,0 +1,156 @@
+package vec
+
+import (
+	"errors"
+
+	"github.com/ncw/gmp"
+	"github.com/sachaservan/paillier"
+)
+
+// The functions below compute distances between secret shared or encrypted vectors.
+// Inner products are computed with DotShares, ShareVec.Dot, Party.Dot and EncryptedVec.Dot
+// and equal the cosine similarity of CosineDistance only when the vectors
+// were normalized (see Vec.Normalize) before being encoded.
+
+// SquaredEuclideanDistanceShares returns shares of the squared euclidean distance
+// between the vectors shared in xs and ys, simulating all parties in-process
+func SquaredEuclideanDistanceShares(xs []*ShareVec, ys []*ShareVec, triples []*BeaverTriple) ([]*gmp.Int, error) {
+
+	if len(xs) != len(ys) {
+		return nil, errors.New("number of shares of x and y do not match")
+	}
+
+	diffs := make([]*ShareVec, len(xs))
+	for i := range xs {
+		diff, err := xs[i].Vec.Clone().Sub(ys[i].Vec)
+		if err != nil {
+			return nil, err
+		}
+
+		if xs[i].Index != ys[i].Index {
+			return nil, errors.New("Index of share a != index of share b")
+		}
+
+		diffs[i] = &ShareVec{diff.Mod(xs[i].P), xs[i].P, xs[i].Index}
+	}
+
+	return DotShares(diffs, diffs, triples)
+}
+
+// SquaredEuclideanDistancePublicShares returns shares of the squared euclidean distance
+// between the vector additively shared in xs and the public vector q, simulating all parties in-process
+func SquaredEuclideanDistancePublicShares(xs []*ShareVec, q *BigVec, triples []*BeaverTriple) ([]*gmp.Int, error) {
+
+	diffs := make([]*ShareVec, len(xs))
+	for i := range xs {
+		var err error
+		if diffs[i], err = xs[i].SubPublic(q); err != nil {
+			return nil, err
+		}
+	}
+
+	return DotShares(diffs, diffs, triples)
+}
+
+// SquaredEuclideanDistance returns the party's share of the squared euclidean distance
+// between the shared vectors x and y using the party's share of a multiplication triple
+func (p *Party) SquaredEuclideanDistance(x *ShareVec, y *ShareVec, triple *BeaverTriple) (*gmp.Int, error) {
+
+	diff, err := x.Vec.Clone().Sub(y.Vec)
+	if err != nil {
+		return nil, err
+	}
+
+	if x.Index != y.Index {
+		return nil, errors.New("Index of share a != index of share b")
+	}
+
+	diffShare := &ShareVec{diff.Mod(x.P), x.P, x.Index}
+
+	return p.Dot(diffShare, diffShare, triple)
+}
+
+// SquaredEuclideanDistancePublic returns the party's share of the squared euclidean distance
+// between the additively shared vector x and the public vector q using the party's share of a multiplication triple
+func (p *Party) SquaredEuclideanDistancePublic(x *ShareVec, q *BigVec, triple *BeaverTriple) (*gmp.Int, error) {
+
+	diff, err := x.SubPublic(q)
+	if err != nil {
+		return nil, err
+	}
+
+	return p.Dot(diff, diff, triple)
+}
+
+// EncryptWithNorm returns an encryption of the vector together with
+// an encryption of its squared norm ||a||^2
+func EncryptWithNorm(a *BigVec, pk *paillier.PublicKey) (*EncryptedVec, Ciphertext) {
+
+	normSq, _ := a.Dot(a)
+	encrypted := Encrypt(a, pk)
+
+	return encrypted, encrypted.Scheme.Encrypt(normSq)
+}
+
+// EncryptedSquaredEuclideanDistance returns an encryption of the squared euclidean distance
+// ||x - q||^2 = ||x||^2 - 2<x, q> + ||q||^2 between the encrypted vector x and the plaintext
+// vector q given an encryption of the squared norm ||x||^2
+func EncryptedSquaredEuclideanDistance(x *EncryptedVec, xNormSq Ciphertext, q *BigVec) (Ciphertext, error) {
+
+	qNormSq, _ := q.Dot(q)
+
+	return encryptedSquaredEuclideanDistance(x, xNormSq, q, x.Scheme.Encrypt(qNormSq))
+}
+
+// BatchEncryptedSquaredEuclideanDistance returns encryptions of the squared euclidean distances
+// between each encrypted vector xs[i] (with encrypted squared norm xNormsSq[i]) and the plaintext
+// vector q, e.g., to score nearest-neighbour candidates
+func BatchEncryptedSquaredEuclideanDistance(xs []*EncryptedVec, xNormsSq []Ciphertext, q *BigVec) ([]Ciphertext, error) {
+
+	if len(xs) != len(xNormsSq) {
+		return nil, errors.New("number of vectors does not match number of norms")
+	}
+
+	if len(xs) == 0 {
+		return []Ciphertext{}, nil
+	}
+
+	// the squared norm of q is only encrypted once for the batch
+	qNormSq, _ := q.Dot(q)
+	encQNormSq := xs[0].Scheme.Encrypt(qNormSq)
+
+	res := make([]Ciphertext, len(xs))
+	for i := range xs {
+		if !xs[i].Scheme.Equal(xs[0].Scheme) {
+			return nil, ErrSchemeMismatch
+		}
+
+		var err error
+		if res[i], err = encryptedSquaredEuclideanDistance(xs[i], xNormsSq[i], q, encQNormSq); err != nil {
+			return nil, err
+		}
+	}
+
+	return res, nil
+}
+
+// encryptedSquaredEuclideanDistance returns an encryption of ||x||^2 - 2<x, q> + ||q||^2
+// given encryptions of ||x||^2 and ||q||^2
+func encryptedSquaredEuclideanDistance(x *EncryptedVec, xNormSq Ciphertext, q *BigVec, qNormSq Ciphertext) (Ciphertext, error) {
+
+	scheme := x.Scheme
+
+	if err := scheme.CheckCiphertext(xNormSq); err != nil {
+		return nil, err
+	}
+
+	dot, err := x.Dot(q)
+	if err != nil {
+		return nil, err
+	}
+
+	res := scheme.Sub(xNormSq, scheme.ConstMult(dot, gmp.NewInt(2)))
+	res = scheme.Add(res, qNormSq)
+
+	return res, nil
+}
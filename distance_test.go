@@ -0,0 +1,192 @@
+package vec
+
+import (
+	"math"
+	"testing"
+
+	"github.com/ncw/gmp"
+	"github.com/sachaservan/paillier"
+)
+
+// squaredEuclideanDistance returns the squared euclidean distance between the integer vectors
+func squaredEuclideanDistance(a, b *BigVec) *gmp.Int {
+	diff, _ := a.Clone().Sub(b)
+	res, _ := diff.Dot(diff)
+	return res
+}
+
+func TestSquaredEuclideanDistanceShares(t *testing.T) {
+
+	field := randomPrime(100)
+	scale := gmp.NewInt(1)
+
+	for trial := 0; trial < 100; trial++ {
+
+		a := NewRandomVec(dim, -100, 100)
+		b := NewRandomVec(dim, -100, 100)
+		aBig := a.ToBigVec(scale)
+		bBig := b.ToBigVec(scale)
+		sharesA := SecretShare(aBig, 3, field)
+		sharesB := SecretShare(bBig, 3, field)
+
+		expected := math.Pow(EuclideanDistance(a, b), 2)
+
+		dists, err := SquaredEuclideanDistanceShares(sharesA, sharesB, NewBeaverTriples(dim, 3, field))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if got := RecoverInt(field, dists...); math.Abs(float64(got.Int64())-expected) > 1e-6 {
+			t.Fatalf("Incorrest result. Expected %v, got %v", expected, got)
+		}
+
+		dists, err = SquaredEuclideanDistancePublicShares(sharesA, bBig, NewBeaverTriples(dim, 3, field))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if got := RecoverInt(field, dists...); math.Abs(float64(got.Int64())-expected) > 1e-6 {
+			t.Fatalf("Incorrest result. Expected %v, got %v", expected, got)
+		}
+	}
+}
+
+func TestCosineSimilarityShares(t *testing.T) {
+
+	field := randomPrime(100)
+	scale := gmp.NewInt(1 << 20)
+
+	for trial := 0; trial < 100; trial++ {
+
+		a := NewRandomVec(dim, -100, 100)
+		b := NewRandomVec(dim, -100, 100)
+		expected := CosineDistance(a, b)
+
+		a.Normalize()
+		b.Normalize()
+		sharesA := SecretShare(a.ToBigVec(scale), 2, field)
+		sharesB := SecretShare(b.ToBigVec(scale), 2, field)
+
+		dots, err := DotShares(sharesA, sharesB, NewBeaverTriples(dim, 2, field))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		got, err := DecodeFixedPoint(RecoverInt(field, dots...), scale, 2)
+		if err != nil || math.Abs(got-expected) > 1e-4 {
+			t.Fatalf("Incorrest result. Expected %v, got %v (err = %v)", expected, got, err)
+		}
+
+		dots = make([]*gmp.Int, 2)
+		for i := range sharesA {
+			dots[i], _ = sharesA[i].Dot(b.ToBigVec(scale))
+		}
+
+		got, err = DecodeFixedPoint(RecoverInt(field, dots...), scale, 2)
+		if err != nil || math.Abs(got-expected) > 1e-4 {
+			t.Fatalf("Incorrest result. Expected %v, got %v (err = %v)", expected, got, err)
+		}
+	}
+}
+
+func TestPartySquaredEuclideanDistance(t *testing.T) {
+
+	field := randomPrime(100)
+	transports := memoryTransports(2)
+	defer transports[0].Close()
+
+	for trial := 0; trial < 10; trial++ {
+
+		aBig := NewBigRandomVec(dim, gmp.NewInt(-100), gmp.NewInt(100))
+		bBig := NewBigRandomVec(dim, gmp.NewInt(-100), gmp.NewInt(100))
+		sharesA := SecretShare(aBig, 2, field)
+		sharesB := SecretShare(bBig, 2, field)
+		triples := NewBeaverTriples(dim, 2, field)
+		publicTriples := NewBeaverTriples(dim, 2, field)
+		expected := squaredEuclideanDistance(aBig, bBig)
+
+		dists := make([]*gmp.Int, 2)
+		publicDists := make([]*gmp.Int, 2)
+		runParties(t, transports, func(party *Party) error {
+			var err error
+			dists[party.ID], err = party.SquaredEuclideanDistance(sharesA[party.ID], sharesB[party.ID], triples[party.ID])
+			if err != nil {
+				return err
+			}
+
+			publicDists[party.ID], err = party.SquaredEuclideanDistancePublic(sharesA[party.ID], bBig, publicTriples[party.ID])
+			return err
+		})
+
+		if got := RecoverInt(field, dists...); got.Cmp(expected) != 0 {
+			t.Fatalf("Incorrest result. Expected %v, got %v", expected, got)
+		}
+
+		if got := RecoverInt(field, publicDists...); got.Cmp(expected) != 0 {
+			t.Fatalf("Incorrest result. Expected %v, got %v", expected, got)
+		}
+	}
+}
+
+func TestEncryptedDistance(t *testing.T) {
+
+	pk, sk := paillier.KeyGen(keyBits)
+
+	for trial := 0; trial < 10; trial++ {
+
+		aBig := NewBigRandomVec(dim, gmp.NewInt(-100), gmp.NewInt(100))
+		qBig := NewBigRandomVec(dim, gmp.NewInt(-100), gmp.NewInt(100))
+		encA, encNormSq := EncryptWithNorm(aBig, pk)
+
+		dist, err := EncryptedSquaredEuclideanDistance(encA, encNormSq, qBig)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		expected := squaredEuclideanDistance(aBig, qBig)
+		if got := sk.Decrypt(mustPaillierCiphertext(dist)); got.Cmp(expected) != 0 {
+			t.Fatalf("Incorrest result. Expected %v, got %v", expected, got)
+		}
+
+		dot, err := encA.Dot(qBig)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		expected, _ = aBig.Dot(qBig)
+		if got := RecoverInt(pk.N, sk.Decrypt(mustPaillierCiphertext(dot))); got.Cmp(expected) != 0 {
+			t.Fatalf("Incorrest result. Expected %v, got %v", expected, got)
+		}
+	}
+}
+
+func TestBatchEncryptedDistance(t *testing.T) {
+
+	pk, sk := paillier.KeyGen(keyBits)
+	numVecs := 10
+
+	qBig := NewBigRandomVec(dim, gmp.NewInt(-100), gmp.NewInt(100))
+	vecs := make([]*BigVec, numVecs)
+	encVecs := make([]*EncryptedVec, numVecs)
+	encNorms := make([]Ciphertext, numVecs)
+	for i := 0; i < numVecs; i++ {
+		vecs[i] = NewBigRandomVec(dim, gmp.NewInt(-100), gmp.NewInt(100))
+		encVecs[i], encNorms[i] = EncryptWithNorm(vecs[i], pk)
+	}
+
+	dists, err := BatchEncryptedSquaredEuclideanDistance(encVecs, encNorms, qBig)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < numVecs; i++ {
+		expected := squaredEuclideanDistance(vecs[i], qBig)
+		if got := sk.Decrypt(mustPaillierCiphertext(dists[i])); got.Cmp(expected) != 0 {
+			t.Fatalf("Incorrest result. Expected %v, got %v", expected, got)
+		}
+	}
+
+	if _, err := BatchEncryptedSquaredEuclideanDistance(encVecs, encNorms[1:], qBig); err == nil {
+		t.Fatalf("Expected error for mismatched number of norms")
+	}
+}
@@ -0,0 +1,312 @@
+package vec
+
+import (
+	"crypto/elliptic"
+	"crypto/rand"
+	"errors"
+	"math/big"
+	"sync"
+
+	"github.com/ncw/gmp"
+)
+
+// ErrPlaintextOutOfRange is returned when an exponential ElGamal
+// plaintext is outside the range that can be decrypted
+var ErrPlaintextOutOfRange = errors.New("plaintext is out of the decryptable range")
+
+// ElGamalCiphertext is an exponential ElGamal ciphertext (r*G, m*G + r*H)
+type ElGamalCiphertext struct {
+	C1x, C1y *big.Int
+	C2x, C2y *big.Int
+}
+
+// ElGamalHE is exponential ElGamal over an elliptic curve as an AdditiveHE scheme.
+// Plaintexts are in Z_n where n is the order of the curve but only plaintexts
+// with absolute value at most Bound can be decrypted (using baby-step giant-step),
+// which makes the scheme suited for small-range values such as binary vectors
+type ElGamalHE struct {
+	Curve  elliptic.Curve
+	Hx, Hy *big.Int // public key H = sk*G
+	Sk     *big.Int // nil if only the public key is known
+	Bound  int64    // bound on the absolute value of decryptable plaintexts
+
+	tableOnce sync.Once
+	table     map[string]int64 // baby steps j*G for 0 <= j < step
+	step      int64
+}
+
+// NewElGamalHE generates a new exponential ElGamal key on the curve (e.g. elliptic.P256())
+// for decrypting plaintexts with absolute value at most bound
+func NewElGamalHE(curve elliptic.Curve, bound int64) (*ElGamalHE, error) {
+
+	if bound < 0 {
+		return nil, errors.New("plaintext bound must be non-negative")
+	}
+
+	sk, err := randomScalar(curve)
+	if err != nil {
+		return nil, err
+	}
+
+	hx, hy := curve.ScalarBaseMult(sk.Bytes())
+
+	return &ElGamalHE{
+		Curve: curve,
+		Hx:    hx,
+		Hy:    hy,
+		Sk:    sk,
+		Bound: bound,
+	}, nil
+}
+
+// PublicKey returns the scheme without the secret key
+func (s *ElGamalHE) PublicKey() *ElGamalHE {
+	return &ElGamalHE{
+		Curve: s.Curve,
+		Hx:    s.Hx,
+		Hy:    s.Hy,
+		Bound: s.Bound,
+	}
+}
+
+// Encrypt returns an encryption of m mod n
+func (s *ElGamalHE) Encrypt(m *gmp.Int) Ciphertext {
+
+	r, err := randomScalar(s.Curve)
+	if err != nil {
+		panic(err)
+	}
+
+	c1x, c1y := s.Curve.ScalarBaseMult(r.Bytes())
+	rhx, rhy := s.Curve.ScalarMult(s.Hx, s.Hy, r.Bytes())
+	mgx, mgy := s.Curve.ScalarBaseMult(s.scalar(m).Bytes())
+	c2x, c2y := s.add(mgx, mgy, rhx, rhy)
+
+	return &ElGamalCiphertext{c1x, c1y, c2x, c2y}
+}
+
+// EncryptZero returns a fresh encryption of zero
+func (s *ElGamalHE) EncryptZero() Ciphertext {
+	return s.Encrypt(gmp.NewInt(0))
+}
+
+// Add returns an encryption of the sum of the plaintexts of a and b
+func (s *ElGamalHE) Add(a, b Ciphertext) Ciphertext {
+
+	ca, cb := mustElGamalCiphertext(s.Curve, a), mustElGamalCiphertext(s.Curve, b)
+	c1x, c1y := s.add(ca.C1x, ca.C1y, cb.C1x, cb.C1y)
+	c2x, c2y := s.add(ca.C2x, ca.C2y, cb.C2x, cb.C2y)
+
+	return &ElGamalCiphertext{c1x, c1y, c2x, c2y}
+}
+
+// Sub returns an encryption of the difference of the plaintexts of a and b
+func (s *ElGamalHE) Sub(a, b Ciphertext) Ciphertext {
+	return s.Add(a, s.ConstMult(b, gmp.NewInt(-1)))
+}
+
+// ConstMult returns an encryption of the plaintext of a times the constant k
+// where k is first reduced modulo n
+func (s *ElGamalHE) ConstMult(a Ciphertext, k *gmp.Int) Ciphertext {
+
+	ca := mustElGamalCiphertext(s.Curve, a)
+	scalar := s.scalar(k).Bytes()
+
+	c1x, c1y := s.scalarMult(ca.C1x, ca.C1y, scalar)
+	c2x, c2y := s.scalarMult(ca.C2x, ca.C2y, scalar)
+
+	return &ElGamalCiphertext{c1x, c1y, c2x, c2y}
+}
+
+// Decrypt returns the plaintext of ct in Z_n
+// throws ErrPlaintextOutOfRange if the absolute value of the plaintext exceeds Bound
+func (s *ElGamalHE) Decrypt(ct Ciphertext) (*gmp.Int, error) {
+
+	if s.Sk == nil {
+		return nil, ErrNoSecretKey
+	}
+
+	c, err := elGamalCiphertext(s.Curve, ct)
+	if err != nil {
+		return nil, err
+	}
+
+	// m*G = C2 - sk*C1
+	skx, sky := s.scalarMult(c.C1x, c.C1y, s.Sk.Bytes())
+	mx, my := s.add(c.C2x, c.C2y, skx, s.negY(skx, sky))
+
+	m, err := s.discreteLog(mx, my)
+	if err != nil {
+		return nil, err
+	}
+
+	res := new(gmp.Int).SetBytes(new(big.Int).Abs(m).Bytes())
+	if m.Sign() < 0 {
+		res.Neg(res)
+	}
+
+	return res.Mod(res, s.PlaintextModulus()), nil
+}
+
+// PlaintextModulus returns the order n of the curve
+func (s *ElGamalHE) PlaintextModulus() *gmp.Int {
+	return new(gmp.Int).SetBytes(s.Curve.Params().N.Bytes())
+}
+
+// CheckCiphertext returns ErrCiphertextType if ct is not an exponential ElGamal ciphertext
+// with both points on the curve of the scheme
+func (s *ElGamalHE) CheckCiphertext(ct Ciphertext) error {
+	_, err := elGamalCiphertext(s.Curve, ct)
+	return err
+}
+
+// Equal returns true if other is exponential ElGamal with the same curve and public key
+func (s *ElGamalHE) Equal(other AdditiveHE) bool {
+	o, ok := other.(*ElGamalHE)
+	return ok && s.Curve.Params().Name == o.Curve.Params().Name &&
+		s.Curve.Params().P.Cmp(o.Curve.Params().P) == 0 &&
+		s.Hx.Cmp(o.Hx) == 0 && s.Hy.Cmp(o.Hy) == 0
+}
+
+// discreteLog returns m in [-Bound, Bound] such that (x, y) = m*G
+// using baby-step giant-step
+func (s *ElGamalHE) discreteLog(x, y *big.Int) (*big.Int, error) {
+
+	s.tableOnce.Do(s.buildTable)
+
+	// search for m + Bound in [0, 2*Bound]
+	boundG := s.scalar(gmp.NewInt(s.Bound)).Bytes()
+	bx, by := s.Curve.ScalarBaseMult(boundG)
+	tx, ty := s.add(x, y, bx, by)
+
+	// giant step -step*G
+	gx, gy := s.Curve.ScalarBaseMult(big.NewInt(s.step).Bytes())
+	gy = s.negY(gx, gy)
+
+	for i := int64(0); i*s.step <= 2*s.Bound; i++ {
+		if j, ok := s.table[pointKey(tx, ty)]; ok {
+			m := i*s.step + j
+			if m <= 2*s.Bound {
+				return big.NewInt(m - s.Bound), nil
+			}
+		}
+
+		tx, ty = s.add(tx, ty, gx, gy)
+	}
+
+	return nil, ErrPlaintextOutOfRange
+}
+
+// buildTable precomputes the baby steps j*G for 0 <= j < step
+// where step is about sqrt(2*Bound + 1)
+func (s *ElGamalHE) buildTable() {
+
+	s.step = 1
+	for s.step*s.step < 2*s.Bound+1 {
+		s.step++
+	}
+
+	s.table = make(map[string]int64, s.step)
+
+	x, y := new(big.Int), new(big.Int)
+	gx, gy := s.Curve.Params().Gx, s.Curve.Params().Gy
+	for j := int64(0); j < s.step; j++ {
+		s.table[pointKey(x, y)] = j
+		x, y = s.add(x, y, gx, gy)
+	}
+}
+
+// scalar returns k mod n as a big.Int
+func (s *ElGamalHE) scalar(k *gmp.Int) *big.Int {
+	mod := new(gmp.Int).Mod(k, s.PlaintextModulus())
+	return new(big.Int).SetBytes(mod.Bytes())
+}
+
+// add returns the sum of the points where (0, 0) is the point at infinity
+func (s *ElGamalHE) add(x1, y1, x2, y2 *big.Int) (*big.Int, *big.Int) {
+
+	if isInfinity(x1, y1) {
+		return new(big.Int).Set(x2), new(big.Int).Set(y2)
+	}
+
+	if isInfinity(x2, y2) {
+		return new(big.Int).Set(x1), new(big.Int).Set(y1)
+	}
+
+	return s.Curve.Add(x1, y1, x2, y2)
+}
+
+// scalarMult returns k*(x, y) where (0, 0) is the point at infinity
+func (s *ElGamalHE) scalarMult(x, y *big.Int, k []byte) (*big.Int, *big.Int) {
+
+	if isInfinity(x, y) {
+		return new(big.Int), new(big.Int)
+	}
+
+	return s.Curve.ScalarMult(x, y, k)
+}
+
+// negY returns the y coordinate of -(x, y)
+func (s *ElGamalHE) negY(x, y *big.Int) *big.Int {
+
+	if isInfinity(x, y) {
+		return new(big.Int)
+	}
+
+	return new(big.Int).Sub(s.Curve.Params().P, y)
+}
+
+// isInfinity returns true if (x, y) is the point at infinity
+func isInfinity(x, y *big.Int) bool {
+	return x.Sign() == 0 && y.Sign() == 0
+}
+
+// onCurve returns true if (x, y) is on the curve or the point at infinity
+func onCurve(curve elliptic.Curve, x, y *big.Int) bool {
+	return isInfinity(x, y) || curve.IsOnCurve(x, y)
+}
+
+// pointKey returns a map key for the point
+func pointKey(x, y *big.Int) string {
+	return x.String() + "," + y.String()
+}
+
+// randomScalar returns a random scalar in [1, n)
+func randomScalar(curve elliptic.Curve) (*big.Int, error) {
+
+	max := new(big.Int).Sub(curve.Params().N, big.NewInt(1))
+	k, err := rand.Int(rand.Reader, max)
+	if err != nil {
+		return nil, err
+	}
+
+	return k.Add(k, big.NewInt(1)), nil
+}
+
+// elGamalCiphertext returns ct as an exponential ElGamal ciphertext on the curve
+// throws ErrCiphertextType if ct is not an exponential ElGamal ciphertext
+// or one of its points is not on the curve
+func elGamalCiphertext(curve elliptic.Curve, ct Ciphertext) (*ElGamalCiphertext, error) {
+
+	c, ok := ct.(*ElGamalCiphertext)
+	if !ok || c == nil || c.C1x == nil || c.C1y == nil || c.C2x == nil || c.C2y == nil {
+		return nil, ErrCiphertextType
+	}
+
+	if !onCurve(curve, c.C1x, c.C1y) || !onCurve(curve, c.C2x, c.C2y) {
+		return nil, ErrCiphertextType
+	}
+
+	return c, nil
+}
+
+// mustElGamalCiphertext is elGamalCiphertext for operations that cannot return an error
+func mustElGamalCiphertext(curve elliptic.Curve, ct Ciphertext) *ElGamalCiphertext {
+
+	c, err := elGamalCiphertext(curve, ct)
+	if err != nil {
+		panic("trying to use a non-ElGamal ciphertext with the ElGamal scheme")
+	}
+
+	return c
+}
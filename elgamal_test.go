@@ -0,0 +1,150 @@
+package vec
+
+import (
+	"crypto/elliptic"
+	"math/big"
+	"testing"
+
+	"github.com/ncw/gmp"
+)
+
+func TestElGamalEncryptDecrypt(t *testing.T) {
+
+	scheme, err := NewElGamalHE(elliptic.P256(), 1000)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, m := range []int64{0, 1, -1, 17, -999, 1000, -1000} {
+		ct := scheme.Encrypt(gmp.NewInt(m))
+
+		res, err := scheme.Decrypt(ct)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if got := RecoverInt(scheme.PlaintextModulus(), res); got.Int64() != m {
+			t.Fatalf("Incorrest result. Expected %v, got %v", m, got)
+		}
+	}
+
+	if _, err := scheme.Decrypt(scheme.Encrypt(gmp.NewInt(1001))); err != ErrPlaintextOutOfRange {
+		t.Fatalf("Expected ErrPlaintextOutOfRange, got %v", err)
+	}
+
+	if _, err := scheme.PublicKey().Decrypt(scheme.Encrypt(gmp.NewInt(1))); err != ErrNoSecretKey {
+		t.Fatalf("Expected ErrNoSecretKey, got %v", err)
+	}
+}
+
+func TestElGamalInvalidCiphertext(t *testing.T) {
+
+	scheme, err := NewElGamalHE(elliptic.P256(), 1000)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// points that are not on the curve
+	forged := &ElGamalCiphertext{big.NewInt(1), big.NewInt(2), big.NewInt(3), big.NewInt(4)}
+	if err := scheme.CheckCiphertext(forged); err != ErrCiphertextType {
+		t.Fatalf("Expected ErrCiphertextType, got %v", err)
+	}
+
+	if _, err := scheme.Decrypt(forged); err != ErrCiphertextType {
+		t.Fatalf("Expected ErrCiphertextType, got %v", err)
+	}
+
+	valid := scheme.Encrypt(gmp.NewInt(5)).(*ElGamalCiphertext)
+	halfForged := &ElGamalCiphertext{valid.C1x, valid.C1y, valid.C2x, new(big.Int).Add(valid.C2y, big.NewInt(1))}
+	if _, err := DecryptWith(NewEncryptedVec(scheme, []Ciphertext{valid, halfForged}), scheme); err != ErrCiphertextType {
+		t.Fatalf("Expected ErrCiphertextType, got %v", err)
+	}
+
+	// the point at infinity is a valid point of a ciphertext
+	zero := &ElGamalCiphertext{new(big.Int), new(big.Int), new(big.Int), new(big.Int)}
+	if res, err := scheme.Decrypt(zero); err != nil || res.Sign() != 0 {
+		t.Fatalf("Incorrest result. Expected 0, got %v (err = %v)", res, err)
+	}
+}
+
+func TestElGamalEncryptedVec(t *testing.T) {
+
+	scheme, err := NewElGamalHE(elliptic.P256(), 1<<21)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	public := scheme.PublicKey()
+
+	for trial := 0; trial < 5; trial++ {
+
+		aBig := NewBigRandomVec(dim, gmp.NewInt(-100), gmp.NewInt(100))
+		bBig := NewBigRandomVec(dim, gmp.NewInt(-100), gmp.NewInt(100))
+		encA := EncryptWith(aBig, public)
+		encB := EncryptWith(bBig, public)
+
+		diff, err := encA.Sub(encB)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		sum, err := diff.Add(encB)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		res, err := DecryptWith(sum, scheme)
+		if err != nil || !res.DecodeSignedValues(scheme.PlaintextModulus()).Equal(aBig) {
+			t.Fatalf("Incorrest result. \nExpected %v \nGot %v (err = %v)", aBig, res, err)
+		}
+
+		dot, err := encA.Dot(bBig)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		expected, _ := aBig.Dot(bBig)
+		got, err := scheme.Decrypt(dot)
+		if err != nil || RecoverInt(scheme.PlaintextModulus(), got).Cmp(expected) != 0 {
+			t.Fatalf("Incorrest result. Expected %v, got %v (err = %v)", expected, got, err)
+		}
+	}
+}
+
+func TestElGamalHammingDistance(t *testing.T) {
+
+	scheme, err := NewElGamalHE(elliptic.P256(), dim)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	scale := gmp.NewInt(1)
+
+	for trial := 0; trial < 5; trial++ {
+
+		p := NewRandomVec(dim, 0, 1)
+		q := NewRandomVec(dim, 0, 1)
+		encP := EncryptWith(p.ToBigVec(scale), scheme.PublicKey())
+
+		// for binary vectors sum_i |p_i - q_i| = <p, 1 - 2q> + sum_i q_i
+		weights := q.Copy().Scale(-2)
+		sumQ := 0.0
+		for i := range weights.Coords {
+			weights.Coords[i]++
+			sumQ += q.Coords[i]
+		}
+
+		dot, err := encP.Dot(weights.ToBigVec(scale))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		dist := scheme.Add(dot, scheme.Encrypt(gmp.NewInt(int64(sumQ))))
+
+		got, err := scheme.Decrypt(dist)
+		expected := HammingDistance(p, q)
+		if err != nil || float64(got.Int64()) != expected {
+			t.Fatalf("Incorrest result. Expected %v, got %v (err = %v)", expected, got, err)
+		}
+	}
+}
@@ -5,62 +5,125 @@ import (
 	"errors"
 	"log"
 	"math/big"
-	"math/rand"
-
-	"time"
 
 	"github.com/ncw/gmp"
 	"github.com/sachaservan/paillier"
 )
 
-// EncryptedVec is a vector of encrypted float64 coordinates
+// EncryptedVec is a vector of encrypted coordinates under
+// an additively homomorphic encryption scheme
 type EncryptedVec struct {
-	Pk     *paillier.PublicKey
-	Coords []*paillier.Ciphertext
+	Scheme AdditiveHE
+	Coords []Ciphertext
 }
 
 // NewEncryptVecWithCoords returns a new encrypted vector with the ciphertexts as coordinates
-func NewEncryptVecWithCoords(pk *paillier.PublicKey, coords []*paillier.Ciphertext) *EncryptedVec {
+func NewEncryptVecWithCoords(scheme AdditiveHE, coords []Ciphertext) *EncryptedVec {
 	return &EncryptedVec{
-		Pk:     pk,
+		Scheme: scheme,
 		Coords: coords,
 	}
 }
 
 // NewEncryptedVec constructs a share of a vector
-func NewEncryptedVec(pk *paillier.PublicKey, coords []*paillier.Ciphertext) *EncryptedVec {
+func NewEncryptedVec(scheme AdditiveHE, coords []Ciphertext) *EncryptedVec {
 	return &EncryptedVec{
 		Coords: coords,
-		Pk:     pk,
+		Scheme: scheme,
 	}
 }
 
-// Encrypt returns an encryption of the vector
+// Encrypt returns a Paillier encryption of the vector
+// (see FixedPoint.Encrypt for vectors of floats)
 func Encrypt(a *BigVec, pk *paillier.PublicKey) *EncryptedVec {
-	encrypted := make([]*paillier.Ciphertext, len(a.Coords))
+	return EncryptWith(a, NewPaillierHE(pk, nil))
+}
+
+// EncryptWith returns an encryption of the vector under the scheme
+func EncryptWith(a *BigVec, scheme AdditiveHE) *EncryptedVec {
+	encrypted := make([]Ciphertext, len(a.Coords))
 
 	for i, coord := range a.Coords {
-		encrypted[i] = pk.Encrypt(coord)
+		encrypted[i] = scheme.Encrypt(coord)
 	}
 
 	return &EncryptedVec{
-		Pk:     pk,
+		Scheme: scheme,
 		Coords: encrypted,
 	}
 }
 
+// Decrypt returns the decryption of the Paillier encrypted vector with coordinates in Z_n
+// throws ErrSchemeMismatch if the vector is not encrypted under the public key of sk
+func Decrypt(a *EncryptedVec, sk *paillier.SecretKey) (*BigVec, error) {
+	return DecryptWith(a, NewPaillierHE(&sk.PublicKey, sk))
+}
+
+// DecryptWith returns the decryption of the vector with coordinates in Z_n
+// where the scheme must know the secret key
+// throws ErrSchemeMismatch if the vector is encrypted under another scheme or key
+func DecryptWith(a *EncryptedVec, scheme AdditiveHE) (*BigVec, error) {
+
+	if !scheme.Equal(a.Scheme) {
+		return nil, ErrSchemeMismatch
+	}
+
+	decrypted := make([]*gmp.Int, len(a.Coords))
+
+	for i, coord := range a.Coords {
+		var err error
+		if decrypted[i], err = scheme.Decrypt(coord); err != nil {
+			return nil, err
+		}
+	}
+
+	return NewBigVec(decrypted), nil
+}
+
+// DecryptSigned returns the decryption of the Paillier encrypted vector where
+// all values > n/2 are treated as negative values
+func DecryptSigned(a *EncryptedVec, sk *paillier.SecretKey) (*BigVec, error) {
+
+	res, err := Decrypt(a, sk)
+	if err != nil {
+		return nil, err
+	}
+
+	return res.DecodeSignedValues(sk.PublicKey.N), nil
+}
+
+// DecryptVec returns the decryption of the Paillier encrypted vector
+// decoded from the fixed-point encoding used in ToBigVec (see FixedPoint.Decrypt
+// for results of products and sums)
+// throws ErrFixedPointWrap if values encoded with the scale factor wrap around n
+func DecryptVec(a *EncryptedVec, sk *paillier.SecretKey, fpScaleFactor *gmp.Int) (*Vec, error) {
+
+	// the fractional part alone must be uniquely represented in Z_n (see FixedPoint.Check)
+	n := sk.PublicKey.N
+	if fpScaleFactor.BitLen() >= n.BitLen()-1 {
+		return nil, ErrFixedPointWrap
+	}
+
+	res, err := Decrypt(a, sk)
+	if err != nil {
+		return nil, err
+	}
+
+	return res.ToVecSigned(n, fpScaleFactor, 1)
+}
+
 // GetCoords returns the big vector of coordinates
-func (a *EncryptedVec) GetCoords() []*paillier.Ciphertext {
+func (a *EncryptedVec) GetCoords() []Ciphertext {
 	return a.Coords
 }
 
 // SetCoords sets the coordinates to the big vector
-func (a *EncryptedVec) SetCoords(v []*paillier.Ciphertext) {
+func (a *EncryptedVec) SetCoords(v []Ciphertext) {
 	a.Coords = v
 }
 
 // GetCoord returns the coordinate at the index
-func (a *EncryptedVec) GetCoord(i int) *paillier.Ciphertext {
+func (a *EncryptedVec) GetCoord(i int) Ciphertext {
 	return a.Coords[i]
 }
 
@@ -70,71 +133,196 @@ func (a *EncryptedVec) Size() int {
 }
 
 // Add returns the component-wise addition of a and b
-// throws an error if the vectors are of different size
+// throws an error if the vectors are of different size or encrypted under different schemes
 func (a *EncryptedVec) Add(b *EncryptedVec) (*EncryptedVec, error) {
 
 	if len(a.Coords) != len(b.Coords) {
 		return nil, errors.New("cannot add vectors of different length")
 	}
 
-	pk := a.Pk
-	res := make([]*paillier.Ciphertext, len(a.Coords))
+	if err := a.checkScheme(b); err != nil {
+		return nil, err
+	}
+
+	scheme := a.Scheme
+	res := make([]Ciphertext, len(a.Coords))
 
 	for i := range a.Coords {
-		res[i] = pk.Add(a.Coords[i], b.Coords[i])
+		res[i] = scheme.Add(a.Coords[i], b.Coords[i])
 	}
 
 	return &EncryptedVec{
-		Pk:     pk,
+		Scheme: scheme,
 		Coords: res,
 	}, nil
 }
 
 // Sub returns the component-wise subtaction of a and b
-// throws an error if the vectors are of different size
+// throws an error if the vectors are of different size or encrypted under different schemes
 func (a *EncryptedVec) Sub(b *EncryptedVec) (*EncryptedVec, error) {
 
 	if len(a.Coords) != len(b.Coords) {
 		return nil, errors.New("cannot add vectors of different length")
 	}
 
-	pk := a.Pk
-	res := make([]*paillier.Ciphertext, len(a.Coords))
+	if err := a.checkScheme(b); err != nil {
+		return nil, err
+	}
+
+	scheme := a.Scheme
+	res := make([]Ciphertext, len(a.Coords))
 
 	for i := range a.Coords {
-		res[i] = pk.Sub(a.Coords[i], b.Coords[i])
+		res[i] = scheme.Sub(a.Coords[i], b.Coords[i])
 	}
 
 	return &EncryptedVec{
-		Pk:     pk,
+		Scheme: scheme,
 		Coords: res,
 	}, nil
 }
 
 // Dot returns the (encrypted) dot product of the two vectors a and b
 // using the homomorphic encryption property of the encrypted vector
-func (a *EncryptedVec) Dot(b *BigVec, pk *paillier.PublicKey) (*paillier.Ciphertext, error) {
+func (a *EncryptedVec) Dot(b *BigVec) (Ciphertext, error) {
 
 	if len(a.Coords) != len(b.Coords) {
 		return nil, errors.New("cannot take dot product of different sized vectors")
 	}
 
-	res := pk.EncryptZero()
+	if err := a.check(); err != nil {
+		return nil, err
+	}
+
+	scheme := a.Scheme
+	res := scheme.EncryptZero()
 	for i := 0; i < len(a.Coords); i++ {
-		res = pk.Add(res, pk.ConstMult(a.Coords[i], b.Coords[i]))
+		res = scheme.Add(res, scheme.ConstMult(a.Coords[i], b.Coords[i]))
 	}
 
 	return res, nil
 }
 
-func shuffle(vals []*paillier.Ciphertext) {
-	r := rand.New(rand.NewSource(time.Now().Unix()))
-	for len(vals) > 0 {
-		n := len(vals)
-		randIndex := r.Intn(n)
-		vals[n-1], vals[randIndex] = vals[randIndex], vals[n-1]
-		vals = vals[:n-1]
+// ScalarMul returns the multiplication of every coordinate of a by the constant c
+func (a *EncryptedVec) ScalarMul(c *gmp.Int) *EncryptedVec {
+
+	scheme := a.Scheme
+	res := make([]Ciphertext, len(a.Coords))
+
+	for i := range a.Coords {
+		res[i] = scheme.ConstMult(a.Coords[i], c)
+	}
+
+	return &EncryptedVec{
+		Scheme: scheme,
+		Coords: res,
+	}
+}
+
+// MulPlain returns the component-wise multiplication of a and the plaintext vector b
+// throws an error if the vectors are of different size
+func (a *EncryptedVec) MulPlain(b *BigVec) (*EncryptedVec, error) {
+
+	if len(a.Coords) != len(b.Coords) {
+		return nil, errors.New("cannot multiply vectors of different length")
+	}
+
+	if err := a.check(); err != nil {
+		return nil, err
+	}
+
+	scheme := a.Scheme
+	res := make([]Ciphertext, len(a.Coords))
+
+	for i := range a.Coords {
+		res[i] = scheme.ConstMult(a.Coords[i], b.Coords[i])
+	}
+
+	return &EncryptedVec{
+		Scheme: scheme,
+		Coords: res,
+	}, nil
+}
+
+// AddPlain returns the component-wise addition of a and the plaintext vector b
+// throws an error if the vectors are of different size
+func (a *EncryptedVec) AddPlain(b *BigVec) (*EncryptedVec, error) {
+
+	if len(a.Coords) != len(b.Coords) {
+		return nil, errors.New("cannot add vectors of different length")
+	}
+
+	if err := a.check(); err != nil {
+		return nil, err
 	}
+
+	scheme := a.Scheme
+	res := make([]Ciphertext, len(a.Coords))
+
+	for i := range a.Coords {
+		res[i] = scheme.Add(a.Coords[i], scheme.Encrypt(b.Coords[i]))
+	}
+
+	return &EncryptedVec{
+		Scheme: scheme,
+		Coords: res,
+	}, nil
+}
+
+// Neg returns the component-wise negation of a
+func (a *EncryptedVec) Neg() *EncryptedVec {
+	return a.ScalarMul(gmp.NewInt(-1))
+}
+
+// Rerandomize returns a fresh encryption of the same vector as a
+// so that the ciphertexts do not leak how they were computed.
+// The randomness is drawn from the pool of the scheme if it has one
+// (see NewPaillierHEWithPool and RerandomizeWithPool)
+func (a *EncryptedVec) Rerandomize() *EncryptedVec {
+
+	scheme := a.Scheme
+	res := make([]Ciphertext, len(a.Coords))
+
+	for i := range a.Coords {
+		res[i] = Rerandomize(scheme, a.Coords[i])
+	}
+
+	return &EncryptedVec{
+		Scheme: scheme,
+		Coords: res,
+	}
+}
+
+// Rerandomize returns a fresh encryption of the same plaintext as ct
+// by adding an encryption of zero (see AdditiveHE.EncryptZero)
+func Rerandomize(scheme AdditiveHE, ct Ciphertext) Ciphertext {
+	return scheme.Add(ct, scheme.EncryptZero())
+}
+
+// check returns ErrCiphertextType if a coordinate is not a ciphertext of the scheme of a
+func (a *EncryptedVec) check() error {
+
+	for _, coord := range a.Coords {
+		if err := a.Scheme.CheckCiphertext(coord); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// checkScheme returns an error if a and b are not encrypted under the same scheme
+func (a *EncryptedVec) checkScheme(b *EncryptedVec) error {
+
+	if !a.Scheme.Equal(b.Scheme) {
+		return ErrSchemeMismatch
+	}
+
+	if err := a.check(); err != nil {
+		return err
+	}
+
+	return b.check()
 }
 
 // generates a new random number < max
@@ -0,0 +1,228 @@
+package vec
+
+import (
+	"testing"
+
+	"github.com/ncw/gmp"
+	"github.com/sachaservan/paillier"
+)
+
+const keyBits = 512
+
+func TestDecrypt(t *testing.T) {
+
+	pk, sk := paillier.KeyGen(keyBits)
+
+	for trial := 0; trial < 10; trial++ {
+
+		aBig := NewBigRandomVec(dim, gmp.NewInt(0), gmp.NewInt(1000))
+		encA := Encrypt(aBig, pk)
+
+		res, err := Decrypt(encA, sk)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !res.Equal(aBig) {
+			t.Fatalf("Incorrest result. \nExpected %v \nGot %v", aBig, res)
+		}
+	}
+}
+
+func TestDecryptSigned(t *testing.T) {
+
+	pk, sk := paillier.KeyGen(keyBits)
+
+	for trial := 0; trial < 10; trial++ {
+
+		aBig := NewBigRandomVec(dim, gmp.NewInt(-1000), gmp.NewInt(1000))
+		encA := Encrypt(aBig, pk)
+
+		res, err := DecryptSigned(encA, sk)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !res.Equal(aBig) {
+			t.Fatalf("Incorrest result. \nExpected %v \nGot %v", aBig, res)
+		}
+	}
+}
+
+func TestDecryptVec(t *testing.T) {
+
+	pk, sk := paillier.KeyGen(keyBits)
+	scale := gmp.NewInt(1 << 20)
+
+	for trial := 0; trial < 10; trial++ {
+
+		a := NewRandomVec(dim, -100, 100).Scale(0.25)
+		encA := Encrypt(a.ToBigVec(scale), pk)
+
+		res, err := DecryptVec(encA, sk, scale)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !res.Equal(a) {
+			t.Fatalf("Incorrest result. \nExpected %v \nGot %v", a.Coords, res.Coords)
+		}
+	}
+
+	// any integer scale factor accepted by ToBigVec can be decoded
+	a := NewVec([]float64{1.234, -0.5, 1000})
+	res, err := DecryptVec(Encrypt(a.ToBigVec(gmp.NewInt(1000)), pk), sk, gmp.NewInt(1000))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !res.Equal(a) {
+		t.Fatalf("Incorrest result. \nExpected %v \nGot %v", a.Coords, res.Coords)
+	}
+
+	tooLarge := new(gmp.Int).Lsh(gmp.NewInt(1), uint(pk.N.BitLen()))
+	if _, err := DecryptVec(Encrypt(a.ToBigVec(gmp.NewInt(1)), pk), sk, tooLarge); err != ErrFixedPointWrap {
+		t.Fatalf("Expected ErrFixedPointWrap, got %v", err)
+	}
+}
+
+func TestEncryptedAddSub(t *testing.T) {
+
+	pk, sk := paillier.KeyGen(keyBits)
+
+	for trial := 0; trial < 10; trial++ {
+
+		aBig := NewBigRandomVec(dim, gmp.NewInt(-1000), gmp.NewInt(1000))
+		bBig := NewBigRandomVec(dim, gmp.NewInt(-1000), gmp.NewInt(1000))
+		encA := Encrypt(aBig, pk)
+		encB := Encrypt(bBig, pk)
+
+		sum, err := encA.Add(encB)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		diff, err := encA.Sub(encB)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		expectedSum, _ := aBig.Clone().Add(bBig)
+		res, err := DecryptSigned(sum, sk)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !res.Equal(expectedSum) {
+			t.Fatalf("Incorrest result. \nExpected %v \nGot %v", expectedSum, res)
+		}
+
+		expectedDiff, _ := aBig.Clone().Sub(bBig)
+		res, err = DecryptSigned(diff, sk)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !res.Equal(expectedDiff) {
+			t.Fatalf("Incorrest result. \nExpected %v \nGot %v", expectedDiff, res)
+		}
+	}
+}
+
+func TestEncryptedPlainOps(t *testing.T) {
+
+	pk, sk := paillier.KeyGen(keyBits)
+
+	for trial := 0; trial < 10; trial++ {
+
+		aBig := NewBigRandomVec(dim, gmp.NewInt(-1000), gmp.NewInt(1000))
+		bBig := NewBigRandomVec(dim, gmp.NewInt(-1000), gmp.NewInt(1000))
+		c := gmp.NewInt(int64(trial - 5))
+		encA := Encrypt(aBig, pk)
+
+		expected := aBig.Clone()
+		for _, coord := range expected.Coords {
+			coord.Mul(coord, c)
+		}
+
+		res, err := DecryptSigned(encA.ScalarMul(c), sk)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !res.Equal(expected) {
+			t.Fatalf("Incorrest result. \nExpected %v \nGot %v", expected, res)
+		}
+
+		prod, err := encA.MulPlain(bBig)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		expected, _ = aBig.Clone().Mul(bBig)
+		res, err = DecryptSigned(prod, sk)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !res.Equal(expected) {
+			t.Fatalf("Incorrest result. \nExpected %v \nGot %v", expected, res)
+		}
+
+		sum, err := encA.AddPlain(bBig)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		expected, _ = aBig.Clone().Add(bBig)
+		res, err = DecryptSigned(sum, sk)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !res.Equal(expected) {
+			t.Fatalf("Incorrest result. \nExpected %v \nGot %v", expected, res)
+		}
+
+		expected, _ = NewBigZeroVec(dim).Sub(aBig)
+		res, err = DecryptSigned(encA.Neg(), sk)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !res.Equal(expected) {
+			t.Fatalf("Incorrest result. \nExpected %v \nGot %v", expected, res)
+		}
+
+		if _, err := encA.MulPlain(NewBigZeroVec(dim + 1)); err == nil {
+			t.Fatalf("Expected error for different sized vectors")
+		}
+	}
+}
+
+func TestRerandomize(t *testing.T) {
+
+	pk, sk := paillier.KeyGen(keyBits)
+
+	for trial := 0; trial < 10; trial++ {
+
+		aBig := NewBigRandomVec(dim, gmp.NewInt(-1000), gmp.NewInt(1000))
+		encA := Encrypt(aBig, pk)
+		res := encA.Rerandomize()
+
+		for i := range encA.Coords {
+			if mustPaillierCiphertext(encA.Coords[i]).C.Cmp(mustPaillierCiphertext(res.Coords[i]).C) == 0 {
+				t.Fatalf("Rerandomized ciphertext equals the original at coordinate %v", i)
+			}
+		}
+
+		got, err := DecryptSigned(res, sk)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !got.Equal(aBig) {
+			t.Fatalf("Incorrest result. \nExpected %v \nGot %v", aBig, got)
+		}
+	}
+}
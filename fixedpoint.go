@@ -0,0 +1,310 @@
+package vec
+
+import (
+	"errors"
+	"math"
+
+	"github.com/ncw/gmp"
+	"github.com/sachaservan/paillier"
+)
+
+// ErrFixedPointWrap is returned when a fixed-point result would wrap around the modulus
+var ErrFixedPointWrap = errors.New("fixed-point result would wrap around the modulus")
+
+// FixedPoint is a fixed-point encoding of floats as signed integers in Z_Modulus
+// with FracBits bits of fractional precision and IntBits bits of integer part
+type FixedPoint struct {
+	FracBits int
+	IntBits  int
+	Modulus  *gmp.Int
+}
+
+// FixedPointVec is a fixed-point encoded vector that tracks the
+// scale accumulated by its coordinates
+type FixedPointVec struct {
+	Vec      *BigVec
+	Depth    int // number of scale factors accumulated by the coordinates
+	Bits     int // bound on the bit length of the coordinates (in absolute value)
+	Encoding *FixedPoint
+}
+
+// FixedPointInt is a fixed-point encoded integer that tracks the
+// scale accumulated by its value
+type FixedPointInt struct {
+	Value    *gmp.Int
+	Depth    int // number of scale factors accumulated by the value
+	Bits     int // bound on the bit length of the value (in absolute value)
+	Encoding *FixedPoint
+}
+
+// NewFixedPoint returns a fixed-point encoding with the given precision
+// where encoded values are signed integers in Z_modulus
+func NewFixedPoint(fracBits int, intBits int, modulus *gmp.Int) (*FixedPoint, error) {
+
+	if modulus == nil {
+		return nil, errors.New("modulus of the fixed-point encoding must not be nil")
+	}
+
+	if fracBits < 0 || intBits < 0 {
+		return nil, errors.New("fixed-point precision must be non-negative")
+	}
+
+	fp := &FixedPoint{
+		FracBits: fracBits,
+		IntBits:  intBits,
+		Modulus:  modulus,
+	}
+
+	if !fp.fits(fracBits + intBits) {
+		return nil, errors.New("modulus is too small for the fixed-point precision")
+	}
+
+	return fp, nil
+}
+
+// ScaleFactor returns the scale factor 2^FracBits used to encode values
+func (fp *FixedPoint) ScaleFactor() *gmp.Int {
+	return new(gmp.Int).Lsh(gmp.NewInt(1), uint(fp.FracBits))
+}
+
+// Bits returns a bound on the bit length of a sum of terms products
+// of depth freshly encoded values
+func (fp *FixedPoint) Bits(depth int, terms int) int {
+	return depth*(fp.FracBits+fp.IntBits) + log2Ceil(terms)
+}
+
+// Check returns an error if a sum of terms products of depth freshly
+// encoded values would wrap around the modulus
+func (fp *FixedPoint) Check(depth int, terms int) error {
+
+	if !fp.fits(fp.Bits(depth, terms)) {
+		return ErrFixedPointWrap
+	}
+
+	return nil
+}
+
+// Encode returns the fixed-point encoding of the vector
+// throws an error if a coordinate is out of the range of the encoding
+func (fp *FixedPoint) Encode(a *Vec) (*FixedPointVec, error) {
+
+	bound := math.Ldexp(1, fp.IntBits)
+	for _, coord := range a.Coords {
+		if math.IsNaN(coord) || math.Abs(coord) >= bound {
+			return nil, errors.New("value is out of the range of the fixed-point encoding")
+		}
+	}
+
+	return &FixedPointVec{
+		Vec:      a.ToBigVec(fp.ScaleFactor()),
+		Depth:    1,
+		Bits:     fp.FracBits + fp.IntBits,
+		Encoding: fp,
+	}, nil
+}
+
+// Decode returns the vector encoded in a (signed) BigVec in Z_Modulus
+// where depth is the number of scale factors accumulated by the coordinates
+func (fp *FixedPoint) Decode(a *BigVec, depth int) (*Vec, error) {
+	return a.ToVecSigned(fp.Modulus, fp.ScaleFactor(), depth)
+}
+
+// SecretShare returns secret shares of the fixed-point encoding of the vector
+// where the modulus of the encoding is used as the prime field
+func (fp *FixedPoint) SecretShare(a *Vec, numShares int) ([]*ShareVec, error) {
+
+	// run 20 tests of Rabin-Miller
+	if !fp.Modulus.ProbablyPrime(20) {
+		return nil, errors.New("trying to secret share in a non-prime order field")
+	}
+
+	encoded, err := fp.Encode(a)
+	if err != nil {
+		return nil, err
+	}
+
+	return SecretShare(encoded.Vec, numShares, fp.Modulus), nil
+}
+
+// RecoverVector outputs the recovered vector from the set of secret shares
+// where every coordinate is a sum of terms products of depth encoded values
+func (fp *FixedPoint) RecoverVector(depth int, terms int, shares ...*ShareVec) (*Vec, error) {
+
+	if len(shares) == 0 {
+		return nil, errors.New("no shares to recover")
+	}
+
+	if err := fp.checkModulus(shares[0].P); err != nil {
+		return nil, err
+	}
+
+	if err := fp.Check(depth, terms); err != nil {
+		return nil, err
+	}
+
+	res, err := RecoverVector(shares...)
+	if err != nil {
+		return nil, err
+	}
+
+	return res.ToVec(fp.ScaleFactor(), depth)
+}
+
+// Encrypt returns an encryption of the fixed-point encoding of the vector
+// where the modulus of the encoding must be the plaintext modulus of pk
+func (fp *FixedPoint) Encrypt(a *Vec, pk *paillier.PublicKey) (*EncryptedVec, error) {
+	return fp.EncryptWith(a, NewPaillierHE(pk, nil))
+}
+
+// EncryptWith returns an encryption of the fixed-point encoding of the vector under the scheme
+// where the modulus of the encoding must be the plaintext modulus of the scheme
+func (fp *FixedPoint) EncryptWith(a *Vec, scheme AdditiveHE) (*EncryptedVec, error) {
+
+	if err := fp.checkModulus(scheme.PlaintextModulus()); err != nil {
+		return nil, err
+	}
+
+	encoded, err := fp.Encode(a)
+	if err != nil {
+		return nil, err
+	}
+
+	return EncryptWith(encoded.Vec, scheme), nil
+}
+
+// Decrypt returns the vector encoded in the encrypted vector
+// where every coordinate is a sum of terms products of depth encoded values
+func (fp *FixedPoint) Decrypt(a *EncryptedVec, sk *paillier.SecretKey, depth int, terms int) (*Vec, error) {
+	return fp.DecryptWith(a, NewPaillierHE(&sk.PublicKey, sk), depth, terms)
+}
+
+// DecryptWith returns the vector encoded in the vector encrypted under the scheme
+// where every coordinate is a sum of terms products of depth encoded values
+func (fp *FixedPoint) DecryptWith(a *EncryptedVec, scheme AdditiveHE, depth int, terms int) (*Vec, error) {
+
+	if err := fp.checkModulus(scheme.PlaintextModulus()); err != nil {
+		return nil, err
+	}
+
+	if err := fp.Check(depth, terms); err != nil {
+		return nil, err
+	}
+
+	res, err := DecryptWith(a, scheme)
+	if err != nil {
+		return nil, err
+	}
+
+	return fp.Decode(res, depth)
+}
+
+// Add returns the component-wise addition of a and b
+// and sets a to the result
+func (a *FixedPointVec) Add(b *FixedPointVec) (*FixedPointVec, error) {
+
+	if a.Depth != b.Depth {
+		return nil, errors.New("cannot add fixed-point values with different scales")
+	}
+
+	bits := maxInt(a.Bits, b.Bits) + 1
+	if !a.Encoding.fits(bits) {
+		return nil, ErrFixedPointWrap
+	}
+
+	if _, err := a.Vec.Add(b.Vec); err != nil {
+		return nil, err
+	}
+
+	a.Bits = bits
+
+	return a, nil
+}
+
+// Mul returns the component-wise multiplication of a and b
+// and sets a to the result
+func (a *FixedPointVec) Mul(b *FixedPointVec) (*FixedPointVec, error) {
+
+	bits := a.Bits + b.Bits
+	if !a.Encoding.fits(bits) {
+		return nil, ErrFixedPointWrap
+	}
+
+	if _, err := a.Vec.Mul(b.Vec); err != nil {
+		return nil, err
+	}
+
+	a.Depth += b.Depth
+	a.Bits = bits
+
+	return a, nil
+}
+
+// Dot returns the dot product of the two vectors a and b
+func (a *FixedPointVec) Dot(b *FixedPointVec) (*FixedPointInt, error) {
+
+	bits := a.Bits + b.Bits + log2Ceil(a.Vec.Size())
+	if !a.Encoding.fits(bits) {
+		return nil, ErrFixedPointWrap
+	}
+
+	res, err := a.Vec.Dot(b.Vec)
+	if err != nil {
+		return nil, err
+	}
+
+	return &FixedPointInt{
+		Value:    res,
+		Depth:    a.Depth + b.Depth,
+		Bits:     bits,
+		Encoding: a.Encoding,
+	}, nil
+}
+
+// Decode returns the vector encoded in a
+func (a *FixedPointVec) Decode() (*Vec, error) {
+	return a.Encoding.Decode(a.Vec, a.Depth)
+}
+
+// Decode returns the float encoded in a
+func (a *FixedPointInt) Decode() (float64, error) {
+	value := NewBigVec([]*gmp.Int{new(gmp.Int).Set(a.Value)})
+
+	res, err := a.Encoding.Decode(value, a.Depth)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.Coords[0], nil
+}
+
+// fits returns true if all signed integers of the bit length
+// are uniquely represented in Z_Modulus
+func (fp *FixedPoint) fits(bits int) bool {
+	return bits < fp.Modulus.BitLen()-1
+}
+
+// checkModulus returns an error if n is not the modulus of the encoding
+func (fp *FixedPoint) checkModulus(n *gmp.Int) error {
+
+	if fp.Modulus.Cmp(n) != 0 {
+		return errors.New("modulus of the fixed-point encoding does not match")
+	}
+
+	return nil
+}
+
+// log2Ceil returns the smallest k such that 2^k >= n
+func log2Ceil(n int) int {
+	k := 0
+	for (1 << uint(k)) < n {
+		k++
+	}
+	return k
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,162 @@
+package vec
+
+import (
+	"testing"
+
+	"github.com/sachaservan/paillier"
+)
+
+func TestFixedPointEncodeDecode(t *testing.T) {
+
+	fp, err := NewFixedPoint(16, 8, randomPrime(100))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for trial := 0; trial < 100; trial++ {
+		a := NewRandomVec(dim, -100, 100).Scale(0.25)
+
+		encoded, err := fp.Encode(a)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		decoded, err := fp.Decode(encoded.Vec.Mod(fp.Modulus), 1)
+		if err != nil || !decoded.Equal(a) {
+			t.Fatalf("Expected %v, got %v (err = %v)\n", a.Coords, decoded, err)
+		}
+	}
+}
+
+func TestFixedPointOutOfRange(t *testing.T) {
+
+	fp, err := NewFixedPoint(16, 4, randomPrime(100))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := fp.Encode(NewVec([]float64{0, 16})); err == nil {
+		t.Fatalf("Expected out of range error\n")
+	}
+
+	if _, err := NewFixedPoint(64, 64, randomPrime(100)); err == nil {
+		t.Fatalf("Expected modulus too small error\n")
+	}
+
+	if _, err := NewFixedPoint(16, 4, nil); err == nil {
+		t.Fatalf("Expected nil modulus error\n")
+	}
+}
+
+func TestFixedPointMulDot(t *testing.T) {
+
+	fp, err := NewFixedPoint(16, 8, randomPrime(60))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for trial := 0; trial < 100; trial++ {
+		a := NewRandomVec(dim, -100, 100).Scale(0.5)
+		b := NewRandomVec(dim, -100, 100).Scale(0.25)
+
+		encA, _ := fp.Encode(a)
+		encB, _ := fp.Encode(b)
+
+		dot, err := encA.Dot(encB)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		got, err := dot.Decode()
+		expected, _ := a.Dot(b)
+		if err != nil || got != expected {
+			t.Fatalf("Expected %v, got %v (err = %v)\n", expected, got, err)
+		}
+
+		prod, err := encA.Mul(encB)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if prod.Depth != 2 {
+			t.Fatalf("Expected depth 2, got %v\n", prod.Depth)
+		}
+
+		// a third multiplication no longer fits in a 60-bit modulus
+		if _, err := prod.Mul(encB); err != ErrFixedPointWrap {
+			t.Fatalf("Expected wrap error, got %v\n", err)
+		}
+	}
+}
+
+func TestFixedPointSecretShare(t *testing.T) {
+
+	fp, err := NewFixedPoint(16, 8, randomPrime(100))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for trial := 0; trial < 100; trial++ {
+		a := NewRandomVec(dim, -100, 100).Scale(0.25)
+		b := NewRandomVec(dim, -100, 100).Scale(0.5)
+
+		shares, err := fp.SecretShare(a, 3)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		res, err := fp.RecoverVector(1, 1, shares...)
+		if err != nil || !res.Equal(a) {
+			t.Fatalf("Expected %v, got %v (err = %v)\n", a.Coords, res, err)
+		}
+
+		bBig := b.ToBigVec(fp.ScaleFactor())
+		for i := range shares {
+			shares[i], _ = shares[i].Mul(bBig)
+		}
+
+		res, err = fp.RecoverVector(2, 1, shares...)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// sums of too many products could wrap around the modulus
+		if _, err := fp.RecoverVector(2, 1<<60, shares...); err != ErrFixedPointWrap {
+			t.Fatalf("Expected wrap error, got %v\n", err)
+		}
+
+		for i := 0; i < a.Size(); i++ {
+			if res.Coords[i] != a.Coords[i]*b.Coords[i] {
+				t.Fatalf("Expected %v, got %v\n", a.Coords[i]*b.Coords[i], res.Coords[i])
+			}
+		}
+	}
+}
+
+func TestFixedPointEncrypt(t *testing.T) {
+
+	pk, sk := paillier.KeyGen(keyBits)
+	fp, err := NewFixedPoint(32, 16, pk.N)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for trial := 0; trial < 10; trial++ {
+		a := NewRandomVec(dim, -100, 100).Scale(0.25)
+
+		encA, err := fp.Encrypt(a, pk)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		res, err := fp.Decrypt(encA, sk, 1, 1)
+		if err != nil || !res.Equal(a) {
+			t.Fatalf("Expected %v, got %v (err = %v)\n", a.Coords, res, err)
+		}
+	}
+
+	other, _ := NewFixedPoint(32, 16, randomPrime(200))
+	if _, err := other.Encrypt(NewRandomVec(dim, -100, 100), pk); err == nil {
+		t.Fatalf("Expected modulus mismatch error\n")
+	}
+}
@@ -0,0 +1,168 @@
+package vec
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ncw/gmp"
+	"github.com/sachaservan/paillier"
+)
+
+// Ciphertext is a ciphertext of an additively homomorphic encryption scheme
+type Ciphertext interface{}
+
+// AdditiveHE is an additively homomorphic encryption scheme
+// with plaintexts in Z_n where n is the plaintext modulus.
+// Add, Sub and ConstMult panic on ciphertexts of another scheme, so ciphertexts
+// from untrusted sources should be checked with CheckCiphertext first
+// (the operations of EncryptedVec that return an error do so)
+type AdditiveHE interface {
+	// Encrypt returns an encryption of m mod n
+	Encrypt(m *gmp.Int) Ciphertext
+
+	// EncryptZero returns a fresh encryption of zero
+	EncryptZero() Ciphertext
+
+	// Add returns an encryption of the sum of the plaintexts of a and b
+	Add(a, b Ciphertext) Ciphertext
+
+	// Sub returns an encryption of the difference of the plaintexts of a and b
+	Sub(a, b Ciphertext) Ciphertext
+
+	// ConstMult returns an encryption of the plaintext of a times the (possibly negative) constant k
+	ConstMult(a Ciphertext, k *gmp.Int) Ciphertext
+
+	// Decrypt returns the plaintext of ct in Z_n
+	// throws an error if the secret key is not known or ct is not a ciphertext of the scheme
+	Decrypt(ct Ciphertext) (*gmp.Int, error)
+
+	// PlaintextModulus returns the plaintext modulus n
+	PlaintextModulus() *gmp.Int
+
+	// CheckCiphertext returns ErrCiphertextType if ct is not a ciphertext of the scheme
+	CheckCiphertext(ct Ciphertext) error
+
+	// Equal returns true if other is the same scheme under the same public key
+	Equal(other AdditiveHE) bool
+}
+
+// ErrNoSecretKey is returned when decrypting without the secret key
+var ErrNoSecretKey = errors.New("secret key is required to decrypt")
+
+// ErrCiphertextType is returned when using a ciphertext with a scheme it does not belong to
+var ErrCiphertextType = errors.New("ciphertext does not belong to the scheme")
+
+// ErrSchemeMismatch is returned when combining values encrypted under different schemes or keys
+var ErrSchemeMismatch = errors.New("values are encrypted under different schemes or keys")
+
+// PaillierHE is the Paillier cryptosystem as an AdditiveHE scheme
+type PaillierHE struct {
+	Pk   *paillier.PublicKey
+	Sk   *paillier.SecretKey // nil if only the public key is known
+	Pool *RandomnessPool     // nil if randomness is not precomputed
+}
+
+// NewPaillierHE returns the Paillier scheme for the keys (sk may be nil)
+func NewPaillierHE(pk *paillier.PublicKey, sk *paillier.SecretKey) *PaillierHE {
+	return &PaillierHE{
+		Pk: pk,
+		Sk: sk,
+	}
+}
+
+// Encrypt returns an encryption of m mod n
+// using a randomizer from the pool if the scheme has one
+func (s *PaillierHE) Encrypt(m *gmp.Int) Ciphertext {
+
+	if s.Pool != nil {
+		if ct, err := s.Pool.Encrypt(context.Background(), m); err == nil {
+			return ct
+		}
+	}
+
+	return s.Pk.Encrypt(new(gmp.Int).Mod(m, s.Pk.N))
+}
+
+// EncryptZero returns a fresh encryption of zero
+// using a randomizer from the pool if the scheme has one
+func (s *PaillierHE) EncryptZero() Ciphertext {
+
+	if s.Pool != nil {
+		if ct, err := s.Pool.EncryptZero(context.Background()); err == nil {
+			return ct
+		}
+	}
+
+	return s.Pk.EncryptZero()
+}
+
+// Add returns an encryption of the sum of the plaintexts of a and b
+func (s *PaillierHE) Add(a, b Ciphertext) Ciphertext {
+	return s.Pk.Add(mustPaillierCiphertext(a), mustPaillierCiphertext(b))
+}
+
+// Sub returns an encryption of the difference of the plaintexts of a and b
+func (s *PaillierHE) Sub(a, b Ciphertext) Ciphertext {
+	return s.Pk.Sub(mustPaillierCiphertext(a), mustPaillierCiphertext(b))
+}
+
+// ConstMult returns an encryption of the plaintext of a times the constant k
+// where k is first reduced modulo n
+func (s *PaillierHE) ConstMult(a Ciphertext, k *gmp.Int) Ciphertext {
+	return s.Pk.ConstMult(mustPaillierCiphertext(a), new(gmp.Int).Mod(k, s.Pk.N))
+}
+
+// Decrypt returns the plaintext of ct in Z_n
+func (s *PaillierHE) Decrypt(ct Ciphertext) (*gmp.Int, error) {
+
+	if s.Sk == nil {
+		return nil, ErrNoSecretKey
+	}
+
+	c, err := paillierCiphertext(ct)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.Sk.Decrypt(c), nil
+}
+
+// PlaintextModulus returns the plaintext modulus n
+func (s *PaillierHE) PlaintextModulus() *gmp.Int {
+	return s.Pk.N
+}
+
+// CheckCiphertext returns ErrCiphertextType if ct is not a Paillier ciphertext
+func (s *PaillierHE) CheckCiphertext(ct Ciphertext) error {
+	_, err := paillierCiphertext(ct)
+	return err
+}
+
+// Equal returns true if other is the Paillier scheme with the same modulus
+func (s *PaillierHE) Equal(other AdditiveHE) bool {
+	o, ok := other.(*PaillierHE)
+	return ok && s.Pk.N.Cmp(o.Pk.N) == 0
+}
+
+// paillierCiphertext returns ct as a Paillier ciphertext
+// throws ErrCiphertextType if ct is not a Paillier ciphertext
+func paillierCiphertext(ct Ciphertext) (*paillier.Ciphertext, error) {
+
+	c, ok := ct.(*paillier.Ciphertext)
+	if !ok || c == nil || c.C == nil {
+		return nil, ErrCiphertextType
+	}
+
+	return c, nil
+}
+
+// mustPaillierCiphertext is paillierCiphertext for operations that cannot return an error
+func mustPaillierCiphertext(ct Ciphertext) *paillier.Ciphertext {
+
+	c, err := paillierCiphertext(ct)
+	if err != nil {
+		panic("trying to use a non-Paillier ciphertext with the Paillier scheme")
+	}
+
+	return c
+}
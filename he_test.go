@@ -0,0 +1,163 @@
+package vec
+
+import (
+	"testing"
+
+	"github.com/ncw/gmp"
+	"github.com/sachaservan/paillier"
+)
+
+// mockHE is an insecure AdditiveHE scheme whose ciphertexts are the plaintexts
+type mockHE struct {
+	n *gmp.Int
+}
+
+func (s *mockHE) Encrypt(m *gmp.Int) Ciphertext { return new(gmp.Int).Mod(m, s.n) }
+func (s *mockHE) EncryptZero() Ciphertext       { return gmp.NewInt(0) }
+
+func (s *mockHE) Add(a, b Ciphertext) Ciphertext {
+	return s.Encrypt(new(gmp.Int).Add(a.(*gmp.Int), b.(*gmp.Int)))
+}
+
+func (s *mockHE) Sub(a, b Ciphertext) Ciphertext {
+	return s.Encrypt(new(gmp.Int).Sub(a.(*gmp.Int), b.(*gmp.Int)))
+}
+
+func (s *mockHE) ConstMult(a Ciphertext, k *gmp.Int) Ciphertext {
+	return s.Encrypt(new(gmp.Int).Mul(a.(*gmp.Int), k))
+}
+
+func (s *mockHE) Decrypt(ct Ciphertext) (*gmp.Int, error) { return ct.(*gmp.Int), nil }
+func (s *mockHE) PlaintextModulus() *gmp.Int              { return s.n }
+
+func (s *mockHE) CheckCiphertext(ct Ciphertext) error {
+	if _, ok := ct.(*gmp.Int); !ok {
+		return ErrCiphertextType
+	}
+	return nil
+}
+
+func (s *mockHE) Equal(other AdditiveHE) bool {
+	o, ok := other.(*mockHE)
+	return ok && s.n.Cmp(o.n) == 0
+}
+
+func TestEncryptedVecWithMockScheme(t *testing.T) {
+
+	scheme := &mockHE{randomPrime(100)}
+
+	for trial := 0; trial < 100; trial++ {
+
+		aBig := NewBigRandomVec(dim, gmp.NewInt(-1000), gmp.NewInt(1000))
+		bBig := NewBigRandomVec(dim, gmp.NewInt(-1000), gmp.NewInt(1000))
+		encA := EncryptWith(aBig, scheme)
+		encB := EncryptWith(bBig, scheme)
+
+		sum, err := encA.Add(encB)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		res, err := DecryptWith(sum.ScalarMul(gmp.NewInt(3)), scheme)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		expected, _ := aBig.Clone().Add(bBig)
+		for _, coord := range expected.Coords {
+			coord.Mul(coord, gmp.NewInt(3))
+		}
+
+		if got := res.DecodeSignedValues(scheme.n); !got.Equal(expected) {
+			t.Fatalf("Incorrest result. \nExpected %v \nGot %v", expected, got)
+		}
+
+		dot, err := encA.Dot(bBig)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		expectedDot, _ := aBig.Dot(bBig)
+		got, _ := scheme.Decrypt(dot)
+		if got = RecoverInt(scheme.n, got); got.Cmp(expectedDot) != 0 {
+			t.Fatalf("Incorrest result. Expected %v, got %v", expectedDot, got)
+		}
+	}
+}
+
+func TestPaillierHE(t *testing.T) {
+
+	pk, sk := paillier.KeyGen(keyBits)
+	scheme := NewPaillierHE(pk, sk)
+
+	aBig := NewBigRandomVec(dim, gmp.NewInt(-1000), gmp.NewInt(1000))
+	encA := EncryptWith(aBig, scheme)
+
+	res, err := DecryptWith(encA, scheme)
+	if err != nil || !res.DecodeSignedValues(pk.N).Equal(aBig) {
+		t.Fatalf("Incorrest result. \nExpected %v \nGot %v (err = %v)", aBig, res, err)
+	}
+
+	if got, err := Decrypt(encA, sk); err != nil || !got.Equal(res) {
+		t.Fatalf("DecryptWith and Decrypt do not match")
+	}
+
+	if _, err := DecryptWith(encA, NewPaillierHE(pk, nil)); err != ErrNoSecretKey {
+		t.Fatalf("Expected ErrNoSecretKey, got %v", err)
+	}
+}
+
+func TestSchemeMismatch(t *testing.T) {
+
+	pk, sk := paillier.KeyGen(keyBits)
+	otherPk, _ := paillier.KeyGen(keyBits)
+
+	aBig := NewBigRandomVec(dim, gmp.NewInt(-1000), gmp.NewInt(1000))
+	encA := Encrypt(aBig, pk)
+
+	if _, err := encA.Add(Encrypt(aBig, otherPk)); err != ErrSchemeMismatch {
+		t.Fatalf("Expected ErrSchemeMismatch, got %v", err)
+	}
+
+	if _, err := encA.Sub(EncryptWith(aBig, &mockHE{pk.N})); err != ErrSchemeMismatch {
+		t.Fatalf("Expected ErrSchemeMismatch, got %v", err)
+	}
+
+	if _, err := DecryptWith(Encrypt(aBig, otherPk), NewPaillierHE(pk, sk)); err != ErrSchemeMismatch {
+		t.Fatalf("Expected ErrSchemeMismatch, got %v", err)
+	}
+
+	// the Paillier helpers check the scheme of the vector against the secret key
+	if _, err := Decrypt(Encrypt(aBig, otherPk), sk); err != ErrSchemeMismatch {
+		t.Fatalf("Expected ErrSchemeMismatch, got %v", err)
+	}
+
+	dj, err := NewDamgardJurikHE(keyBits, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := DecryptSigned(EncryptWith(aBig, dj), sk); err != ErrSchemeMismatch {
+		t.Fatalf("Expected ErrSchemeMismatch, got %v", err)
+	}
+
+	p := randomPrime(64)
+	if _, err := DecryptShared(NewEncryptedShareSum(Encrypt(aBig, otherPk), p), sk); err != ErrSchemeMismatch {
+		t.Fatalf("Expected ErrSchemeMismatch, got %v", err)
+	}
+
+	// a vector claiming the Paillier scheme with ciphertexts of another scheme
+	forged := NewEncryptedVec(encA.Scheme, EncryptWith(aBig, &mockHE{pk.N}).Coords)
+
+	if _, err := encA.Add(forged); err != ErrCiphertextType {
+		t.Fatalf("Expected ErrCiphertextType, got %v", err)
+	}
+
+	if _, err := forged.Dot(aBig); err != ErrCiphertextType {
+		t.Fatalf("Expected ErrCiphertextType, got %v", err)
+	}
+
+	if _, err := DecryptWith(forged, NewPaillierHE(pk, sk)); err != ErrCiphertextType {
+		t.Fatalf("Expected ErrCiphertextType, got %v", err)
+	}
+}
@@ -0,0 +1,111 @@
+package vec
+
+import (
+	"errors"
+)
+
+// BigMatrix is a matrix of big integers stored as a slice of rows
+type BigMatrix struct {
+	Rows []*BigVec
+}
+
+// NewBigMatrix returns a matrix with the rows
+func NewBigMatrix(rows []*BigVec) *BigMatrix {
+	return &BigMatrix{
+		Rows: rows,
+	}
+}
+
+// NumRows returns the number of rows of the matrix
+func (m *BigMatrix) NumRows() int {
+	return len(m.Rows)
+}
+
+// NumCols returns the number of columns of the matrix
+func (m *BigMatrix) NumCols() int {
+	if len(m.Rows) == 0 {
+		return 0
+	}
+	return m.Rows[0].Size()
+}
+
+// MulVec returns the matrix-vector product m * b
+func (m *BigMatrix) MulVec(b *BigVec) (*BigVec, error) {
+
+	if err := m.checkShape(b.Size()); err != nil {
+		return nil, err
+	}
+
+	res := NewBigZeroVec(m.NumRows())
+	for i, row := range m.Rows {
+		dot, err := row.Dot(b)
+		if err != nil {
+			return nil, err
+		}
+		res.Coords[i] = dot
+	}
+
+	return res, nil
+}
+
+// MulEncrypted returns the (encrypted) matrix-vector product m * Enc(x)
+// where the rows are computed in parallel
+func (m *BigMatrix) MulEncrypted(x *EncryptedVec) (*EncryptedVec, error) {
+
+	if err := m.checkShape(x.Size()); err != nil {
+		return nil, err
+	}
+
+	if err := x.check(); err != nil {
+		return nil, err
+	}
+
+	res := make([]Ciphertext, m.NumRows())
+	parallelFor(m.NumRows(), func(i int) {
+		// the shape and ciphertexts were checked above so Dot cannot fail
+		res[i], _ = x.Dot(m.Rows[i])
+	})
+
+	return NewEncryptedVec(x.Scheme, res), nil
+}
+
+// MulMatrix returns the (encrypted) vector-matrix product Enc(x)^T * m
+// where the columns are computed in parallel
+func (x *EncryptedVec) MulMatrix(m *BigMatrix) (*EncryptedVec, error) {
+
+	if m.NumRows() != x.Size() {
+		return nil, errors.New("number of rows of the matrix does not match the size of the vector")
+	}
+
+	if err := m.checkShape(m.NumCols()); err != nil {
+		return nil, err
+	}
+
+	if err := x.check(); err != nil {
+		return nil, err
+	}
+
+	scheme := x.Scheme
+	res := make([]Ciphertext, m.NumCols())
+	parallelFor(m.NumCols(), func(j int) {
+		sum := scheme.EncryptZero()
+		for i, row := range m.Rows {
+			sum = scheme.Add(sum, scheme.ConstMult(x.Coords[i], row.Coords[j]))
+		}
+		res[j] = sum
+	})
+
+	return NewEncryptedVec(scheme, res), nil
+}
+
+// checkShape returns an error if a row of the matrix does not have numCols columns
+func (m *BigMatrix) checkShape(numCols int) error {
+
+	for _, row := range m.Rows {
+		if row.Size() != numCols {
+			return errors.New("number of columns of the matrix does not match the size of the vector")
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,106 @@
+package vec
+
+import (
+	"testing"
+
+	"github.com/ncw/gmp"
+	"github.com/sachaservan/paillier"
+)
+
+func newBigRandomMatrix(numRows int, numCols int) *BigMatrix {
+	rows := make([]*BigVec, numRows)
+	for i := range rows {
+		rows[i] = NewBigRandomVec(numCols, gmp.NewInt(-100), gmp.NewInt(100))
+	}
+	return NewBigMatrix(rows)
+}
+
+// transpose returns the transpose of the matrix
+func transpose(m *BigMatrix) *BigMatrix {
+	rows := make([]*BigVec, m.NumCols())
+	for j := range rows {
+		rows[j] = NewBigZeroVec(m.NumRows())
+		for i := 0; i < m.NumRows(); i++ {
+			rows[j].Coords[i] = m.Rows[i].Coords[j]
+		}
+	}
+	return NewBigMatrix(rows)
+}
+
+func TestMatrixMulVec(t *testing.T) {
+
+	m := NewBigMatrix([]*BigVec{
+		NewBigVec([]*gmp.Int{gmp.NewInt(1), gmp.NewInt(2)}),
+		NewBigVec([]*gmp.Int{gmp.NewInt(-3), gmp.NewInt(4)}),
+		NewBigVec([]*gmp.Int{gmp.NewInt(0), gmp.NewInt(5)}),
+	})
+	b := NewBigVec([]*gmp.Int{gmp.NewInt(2), gmp.NewInt(-1)})
+
+	res, err := m.MulVec(b)
+	expected := NewBigVec([]*gmp.Int{gmp.NewInt(0), gmp.NewInt(-10), gmp.NewInt(-5)})
+	if err != nil || !res.Equal(expected) {
+		t.Fatalf("Incorrest result. \nExpected %v \nGot %v (err = %v)", expected, res, err)
+	}
+
+	if _, err := m.MulVec(NewBigZeroVec(3)); err == nil {
+		t.Fatalf("Expected error for mismatched dimensions")
+	}
+}
+
+func TestMatrixMulEncrypted(t *testing.T) {
+
+	pk, sk := paillier.KeyGen(keyBits)
+
+	for trial := 0; trial < 5; trial++ {
+
+		m := newBigRandomMatrix(8, 20)
+		xBig := NewBigRandomVec(20, gmp.NewInt(-100), gmp.NewInt(100))
+		encX := Encrypt(xBig, pk)
+
+		res, err := m.MulEncrypted(encX)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		expected, _ := m.MulVec(xBig)
+		got, err := DecryptSigned(res, sk)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !got.Equal(expected) {
+			t.Fatalf("Incorrest result. \nExpected %v \nGot %v", expected, got)
+		}
+	}
+}
+
+func TestEncryptedMulMatrix(t *testing.T) {
+
+	pk, sk := paillier.KeyGen(keyBits)
+
+	for trial := 0; trial < 5; trial++ {
+
+		m := newBigRandomMatrix(20, 8)
+		xBig := NewBigRandomVec(20, gmp.NewInt(-100), gmp.NewInt(100))
+		encX := Encrypt(xBig, pk)
+
+		res, err := encX.MulMatrix(m)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		expected, _ := transpose(m).MulVec(xBig)
+		got, err := DecryptSigned(res, sk)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !got.Equal(expected) {
+			t.Fatalf("Incorrest result. \nExpected %v \nGot %v", expected, got)
+		}
+
+		if _, err := encX.MulMatrix(transpose(m)); err == nil {
+			t.Fatalf("Expected error for mismatched dimensions")
+		}
+	}
+}
@@ -0,0 +1,235 @@
+package vec
+
+import (
+	"errors"
+
+	"github.com/ncw/gmp"
+	"github.com/sachaservan/paillier"
+)
+
+// ErrSlotOverflow is returned when a slot-wise operation on a packed
+// encrypted vector would exceed the headroom of the slots
+var ErrSlotOverflow = errors.New("packed result would overflow the slot headroom")
+
+// PackedEncryptedVec is an encrypted vector where every ciphertext packs several
+// coordinates into SlotBits-bit slots of the plaintext, i.e. the plaintext of a
+// ciphertext is sum_j x_j * 2^(j*SlotBits) for the coordinates x_j in the ciphertext
+type PackedEncryptedVec struct {
+	Scheme   AdditiveHE
+	Coords   []Ciphertext
+	Dim      int // number of packed coordinates
+	SlotBits int // bit length of a slot (including the sign bit)
+	Slots    int // number of slots per ciphertext
+	Bits     int // bound on the bit length of the coordinates (in absolute value)
+}
+
+// EncryptPacked returns a packed Paillier encryption of the vector
+// (see EncryptPackedWith)
+func EncryptPacked(a *BigVec, pk *paillier.PublicKey, valueBits int, headroomBits int) (*PackedEncryptedVec, error) {
+	return EncryptPackedWith(a, NewPaillierHE(pk, nil), valueBits, headroomBits)
+}
+
+// EncryptPackedWith returns a packed encryption of the vector under the scheme
+// where every coordinate must have absolute value < 2^valueBits and
+// headroomBits extra bits per slot are reserved for the growth of
+// the coordinates under slot-wise operations
+func EncryptPackedWith(a *BigVec, scheme AdditiveHE, valueBits int, headroomBits int) (*PackedEncryptedVec, error) {
+
+	if valueBits < 0 || headroomBits < 0 {
+		return nil, errors.New("slot sizes must be non-negative")
+	}
+
+	for _, coord := range a.Coords {
+		if new(gmp.Int).Abs(coord).BitLen() > valueBits {
+			return nil, errors.New("value is out of the range of the slots")
+		}
+	}
+
+	slotBits := valueBits + headroomBits + 1
+
+	// keep one bit of the plaintext for the sign of the packed value
+	slots := (scheme.PlaintextModulus().BitLen() - 2) / slotBits
+	if slots < 1 {
+		return nil, errors.New("plaintext modulus is too small for the slot size")
+	}
+
+	numCiphertexts := (a.Size() + slots - 1) / slots
+	coords := make([]Ciphertext, numCiphertexts)
+	for i := range coords {
+		coords[i] = scheme.Encrypt(pack(a.Coords[i*slots:minInt((i+1)*slots, a.Size())], slotBits))
+	}
+
+	return &PackedEncryptedVec{
+		Scheme:   scheme,
+		Coords:   coords,
+		Dim:      a.Size(),
+		SlotBits: slotBits,
+		Slots:    slots,
+		Bits:     valueBits,
+	}, nil
+}
+
+// DecryptPacked returns the decryption of the packed Paillier encrypted vector
+func DecryptPacked(a *PackedEncryptedVec, sk *paillier.SecretKey) (*BigVec, error) {
+	return DecryptPackedWith(a, NewPaillierHE(&sk.PublicKey, sk))
+}
+
+// DecryptPackedWith returns the decryption of the packed encrypted vector
+// unpacked into (signed) coordinates where the scheme must know the secret key
+func DecryptPackedWith(a *PackedEncryptedVec, scheme AdditiveHE) (*BigVec, error) {
+
+	n := scheme.PlaintextModulus()
+	res := make([]*gmp.Int, 0, a.Dim)
+
+	for _, coord := range a.Coords {
+		m, err := scheme.Decrypt(coord)
+		if err != nil {
+			return nil, err
+		}
+
+		res = append(res, unpack(RecoverInt(n, m), a.SlotBits, minInt(a.Slots, a.Dim-len(res)))...)
+	}
+
+	return NewBigVec(res), nil
+}
+
+// Size returns the dimentionality of the vector
+func (a *PackedEncryptedVec) Size() int {
+	return a.Dim
+}
+
+// Add returns the slot-wise addition of a and b
+// throws ErrSlotOverflow if the result would exceed the slot headroom
+func (a *PackedEncryptedVec) Add(b *PackedEncryptedVec) (*PackedEncryptedVec, error) {
+
+	if err := a.checkLayout(b); err != nil {
+		return nil, err
+	}
+
+	bits := maxInt(a.Bits, b.Bits) + 1
+	if bits >= a.SlotBits {
+		return nil, ErrSlotOverflow
+	}
+
+	res := make([]Ciphertext, len(a.Coords))
+	for i := range a.Coords {
+		res[i] = a.Scheme.Add(a.Coords[i], b.Coords[i])
+	}
+
+	return a.withCoords(res, bits), nil
+}
+
+// Sub returns the slot-wise subtraction of a and b
+// throws ErrSlotOverflow if the result would exceed the slot headroom
+func (a *PackedEncryptedVec) Sub(b *PackedEncryptedVec) (*PackedEncryptedVec, error) {
+
+	if err := a.checkLayout(b); err != nil {
+		return nil, err
+	}
+
+	bits := maxInt(a.Bits, b.Bits) + 1
+	if bits >= a.SlotBits {
+		return nil, ErrSlotOverflow
+	}
+
+	res := make([]Ciphertext, len(a.Coords))
+	for i := range a.Coords {
+		res[i] = a.Scheme.Sub(a.Coords[i], b.Coords[i])
+	}
+
+	return a.withCoords(res, bits), nil
+}
+
+// ScalarMul returns the multiplication of every slot of a by the constant c
+// throws ErrSlotOverflow if the result would exceed the slot headroom
+func (a *PackedEncryptedVec) ScalarMul(c *gmp.Int) (*PackedEncryptedVec, error) {
+
+	bits := a.Bits + new(gmp.Int).Abs(c).BitLen()
+	if bits >= a.SlotBits {
+		return nil, ErrSlotOverflow
+	}
+
+	res := make([]Ciphertext, len(a.Coords))
+	for i := range a.Coords {
+		res[i] = a.Scheme.ConstMult(a.Coords[i], c)
+	}
+
+	return a.withCoords(res, bits), nil
+}
+
+// checkLayout returns an error if a and b are not packed the same way
+func (a *PackedEncryptedVec) checkLayout(b *PackedEncryptedVec) error {
+
+	if a.Dim != b.Dim {
+		return errors.New("cannot add vectors of different length")
+	}
+
+	if a.SlotBits != b.SlotBits || a.Slots != b.Slots {
+		return errors.New("cannot combine vectors with different slot sizes")
+	}
+
+	if !a.Scheme.Equal(b.Scheme) {
+		return ErrSchemeMismatch
+	}
+
+	if err := NewEncryptedVec(a.Scheme, a.Coords).check(); err != nil {
+		return err
+	}
+
+	return NewEncryptedVec(b.Scheme, b.Coords).check()
+}
+
+// withCoords returns a packed vector with the layout of a and the ciphertexts as coordinates
+func (a *PackedEncryptedVec) withCoords(coords []Ciphertext, bits int) *PackedEncryptedVec {
+	return &PackedEncryptedVec{
+		Scheme:   a.Scheme,
+		Coords:   coords,
+		Dim:      a.Dim,
+		SlotBits: a.SlotBits,
+		Slots:    a.Slots,
+		Bits:     bits,
+	}
+}
+
+// pack returns sum_j values_j * 2^(j*slotBits)
+func pack(values []*gmp.Int, slotBits int) *gmp.Int {
+
+	res := gmp.NewInt(0)
+	for j := len(values) - 1; j >= 0; j-- {
+		res.Lsh(res, uint(slotBits))
+		res.Add(res, values[j])
+	}
+
+	return res
+}
+
+// unpack returns the first count signed slot values of the packed value
+// where every slot value x_j satisfies |x_j| < 2^(slotBits-1)
+func unpack(packed *gmp.Int, slotBits int, count int) []*gmp.Int {
+
+	base := new(gmp.Int).Lsh(gmp.NewInt(1), uint(slotBits))
+	half := new(gmp.Int).Rsh(base, 1)
+
+	rest := new(gmp.Int).Set(packed)
+	res := make([]*gmp.Int, count)
+	for j := range res {
+
+		// lowest slot as a signed value in [-2^(slotBits-1), 2^(slotBits-1))
+		res[j] = new(gmp.Int).Mod(rest, base)
+		if res[j].Cmp(half) >= 0 {
+			res[j].Sub(res[j], base)
+		}
+
+		rest.Sub(rest, res[j])
+		rest.Quo(rest, base)
+	}
+
+	return res
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
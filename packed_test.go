@@ -0,0 +1,142 @@
+package vec
+
+import (
+	"testing"
+
+	"github.com/ncw/gmp"
+	"github.com/sachaservan/paillier"
+)
+
+func TestPackedDecrypt(t *testing.T) {
+
+	pk, sk := paillier.KeyGen(keyBits)
+
+	for trial := 0; trial < 10; trial++ {
+
+		aBig := NewBigRandomVec(dim, gmp.NewInt(-(1<<16)+1), gmp.NewInt(1<<16))
+		encA, err := EncryptPacked(aBig, pk, 16, 8)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if encA.Slots*encA.SlotBits > keyBits || len(encA.Coords) >= dim {
+			t.Fatalf("Unexpected packing: %v slots of %v bits", encA.Slots, encA.SlotBits)
+		}
+
+		res, err := DecryptPacked(encA, sk)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !res.Equal(aBig) {
+			t.Fatalf("Incorrest result. \nExpected %v \nGot %v", aBig, res)
+		}
+	}
+
+	if _, err := EncryptPacked(NewBigVec([]*gmp.Int{gmp.NewInt(1 << 16)}), pk, 16, 8); err == nil {
+		t.Fatalf("Expected an error for a value out of the range of the slots")
+	}
+}
+
+func TestPackedAddSubScalarMul(t *testing.T) {
+
+	pk, sk := paillier.KeyGen(keyBits)
+
+	for trial := 0; trial < 10; trial++ {
+
+		aBig := NewBigRandomVec(dim, gmp.NewInt(-(1<<16)+1), gmp.NewInt(1<<16))
+		bBig := NewBigRandomVec(dim, gmp.NewInt(-(1<<16)+1), gmp.NewInt(1<<16))
+		c := gmp.NewInt(-37)
+
+		encA, err := EncryptPacked(aBig, pk, 16, 8)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		encB, err := EncryptPacked(bBig, pk, 16, 8)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		sum, err := encA.Add(encB)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		diff, err := sum.Sub(encB)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		prod, err := diff.ScalarMul(c)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		resSum, err := DecryptPacked(sum, sk)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		resProd, err := DecryptPacked(prod, sk)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		expectedSum := aBig.Clone()
+		expectedSum.Add(bBig)
+		if !resSum.Equal(expectedSum) {
+			t.Fatalf("Incorrest result. \nExpected %v \nGot %v", expectedSum, resSum)
+		}
+
+		expectedProd := aBig.Clone()
+		for _, coord := range expectedProd.Coords {
+			coord.Mul(coord, c)
+		}
+		if !resProd.Equal(expectedProd) {
+			t.Fatalf("Incorrest result. \nExpected %v \nGot %v", expectedProd, resProd)
+		}
+	}
+}
+
+func TestPackedSlotOverflow(t *testing.T) {
+
+	pk, _ := paillier.KeyGen(keyBits)
+
+	aBig := NewBigRandomVec(dim, gmp.NewInt(-(1<<16)+1), gmp.NewInt(1<<16))
+	encA, err := EncryptPacked(aBig, pk, 16, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sum, err := encA.Add(encA)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := sum.Add(sum); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := sum.Add(sum); err != nil {
+		t.Fatal(err)
+	}
+
+	sum, _ = sum.Add(sum)
+	if _, err := sum.Add(sum); err != ErrSlotOverflow {
+		t.Fatalf("Expected ErrSlotOverflow, got %v", err)
+	}
+
+	if _, err := encA.ScalarMul(gmp.NewInt(1 << 3)); err != ErrSlotOverflow {
+		t.Fatalf("Expected ErrSlotOverflow, got %v", err)
+	}
+
+	encB, err := EncryptPacked(aBig, pk, 16, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := encA.Add(encB); err == nil {
+		t.Fatalf("Expected an error for different slot sizes")
+	}
+}
@@ -0,0 +1,182 @@
+package vec
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"sync"
+
+	"github.com/ncw/gmp"
+	"github.com/sachaservan/paillier"
+)
+
+// The functions below are worker-pool variants of the operations on EncryptedVec.
+// They produce the same results as the serial operations and use the given number
+// of workers (one per CPU if workers <= 0). They stop early and return the context's
+// error if ctx is cancelled.
+
+// EncryptParallel returns an encryption of the vector
+func EncryptParallel(ctx context.Context, a *BigVec, pk *paillier.PublicKey, workers int) (*EncryptedVec, error) {
+
+	scheme := NewPaillierHE(pk, nil)
+	encrypted := make([]Ciphertext, len(a.Coords))
+	err := parallelForContext(ctx, len(a.Coords), workers, func(i int) {
+		encrypted[i] = scheme.Encrypt(a.Coords[i])
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return NewEncryptedVec(scheme, encrypted), nil
+}
+
+// DecryptParallel returns the decryption of the vector with coordinates in Z_n
+// throws ErrSchemeMismatch if the vector is not encrypted under the public key of sk
+func DecryptParallel(ctx context.Context, a *EncryptedVec, sk *paillier.SecretKey, workers int) (*BigVec, error) {
+
+	if !NewPaillierHE(&sk.PublicKey, sk).Equal(a.Scheme) {
+		return nil, ErrSchemeMismatch
+	}
+
+	// the scheme of a is Paillier, so this checks for Paillier ciphertexts
+	if err := a.check(); err != nil {
+		return nil, err
+	}
+
+	decrypted := make([]*gmp.Int, len(a.Coords))
+	err := parallelForContext(ctx, len(a.Coords), workers, func(i int) {
+		decrypted[i] = sk.Decrypt(mustPaillierCiphertext(a.Coords[i]))
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return NewBigVec(decrypted), nil
+}
+
+// AddParallel returns the component-wise addition of a and b
+// throws an error if the vectors are of different size or encrypted under different schemes
+func (a *EncryptedVec) AddParallel(ctx context.Context, b *EncryptedVec, workers int) (*EncryptedVec, error) {
+
+	if len(a.Coords) != len(b.Coords) {
+		return nil, errors.New("cannot add vectors of different length")
+	}
+
+	if err := a.checkScheme(b); err != nil {
+		return nil, err
+	}
+
+	scheme := a.Scheme
+	res := make([]Ciphertext, len(a.Coords))
+	err := parallelForContext(ctx, len(a.Coords), workers, func(i int) {
+		res[i] = scheme.Add(a.Coords[i], b.Coords[i])
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return NewEncryptedVec(scheme, res), nil
+}
+
+// SubParallel returns the component-wise subtaction of a and b
+// throws an error if the vectors are of different size or encrypted under different schemes
+func (a *EncryptedVec) SubParallel(ctx context.Context, b *EncryptedVec, workers int) (*EncryptedVec, error) {
+
+	if len(a.Coords) != len(b.Coords) {
+		return nil, errors.New("cannot add vectors of different length")
+	}
+
+	if err := a.checkScheme(b); err != nil {
+		return nil, err
+	}
+
+	scheme := a.Scheme
+	res := make([]Ciphertext, len(a.Coords))
+	err := parallelForContext(ctx, len(a.Coords), workers, func(i int) {
+		res[i] = scheme.Sub(a.Coords[i], b.Coords[i])
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return NewEncryptedVec(scheme, res), nil
+}
+
+// DotParallel returns the (encrypted) dot product of the two vectors a and b
+func (a *EncryptedVec) DotParallel(ctx context.Context, b *BigVec, workers int) (Ciphertext, error) {
+
+	if len(a.Coords) != len(b.Coords) {
+		return nil, errors.New("cannot take dot product of different sized vectors")
+	}
+
+	if err := a.check(); err != nil {
+		return nil, err
+	}
+
+	scheme := a.Scheme
+	prods := make([]Ciphertext, len(a.Coords))
+	err := parallelForContext(ctx, len(a.Coords), workers, func(i int) {
+		prods[i] = scheme.ConstMult(a.Coords[i], b.Coords[i])
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	// homomorphic additions are cheap compared to ConstMult
+	res := scheme.EncryptZero()
+	for _, prod := range prods {
+		res = scheme.Add(res, prod)
+	}
+
+	return res, nil
+}
+
+// parallelFor calls fn(i) for every 0 <= i < n using one worker per CPU
+func parallelFor(n int, fn func(i int)) {
+	parallelForContext(context.Background(), n, 0, fn)
+}
+
+// parallelForContext calls fn(i) for every 0 <= i < n using the given number of workers
+// (one per CPU if workers <= 0) and stops early if ctx is cancelled
+func parallelForContext(ctx context.Context, n int, workers int, fn func(i int)) error {
+
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+
+	indices := make(chan int)
+	var wg sync.WaitGroup
+
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indices {
+				fn(i)
+			}
+		}()
+	}
+
+	var err error
+	for i := 0; i < n && err == nil; i++ {
+		if err = ctx.Err(); err != nil {
+			break
+		}
+
+		select {
+		case indices <- i:
+		case <-ctx.Done():
+			err = ctx.Err()
+		}
+	}
+	close(indices)
+
+	wg.Wait()
+
+	return err
+}
@@ -0,0 +1,176 @@
+package vec
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ncw/gmp"
+	"github.com/sachaservan/paillier"
+)
+
+func TestEncryptDecryptParallel(t *testing.T) {
+
+	pk, sk := paillier.KeyGen(keyBits)
+	ctx := context.Background()
+
+	for _, workers := range []int{0, 1, 3} {
+
+		aBig := NewBigRandomVec(dim, gmp.NewInt(-1000), gmp.NewInt(1000))
+
+		encA, err := EncryptParallel(ctx, aBig, pk, workers)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		res, err := DecryptParallel(ctx, encA, sk, workers)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		expected, err := Decrypt(encA, sk)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !res.Equal(expected) || !res.DecodeSignedValues(pk.N).Equal(aBig) {
+			t.Fatalf("Incorrest result. \nExpected %v \nGot %v", aBig, res)
+		}
+	}
+}
+
+func TestDecryptParallelSchemeMismatch(t *testing.T) {
+
+	pk, sk := paillier.KeyGen(keyBits)
+	ctx := context.Background()
+
+	aBig := NewBigRandomVec(dim, gmp.NewInt(-1000), gmp.NewInt(1000))
+
+	otherPk, _ := paillier.KeyGen(keyBits)
+	if _, err := DecryptParallel(ctx, Encrypt(aBig, otherPk), sk, 2); err != ErrSchemeMismatch {
+		t.Fatalf("Expected ErrSchemeMismatch, got %v", err)
+	}
+
+	dj, err := NewDamgardJurikHE(keyBits, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := DecryptParallel(ctx, EncryptWith(aBig, dj), sk, 2); err != ErrSchemeMismatch {
+		t.Fatalf("Expected ErrSchemeMismatch, got %v", err)
+	}
+
+	// a vector claiming the key of sk with ciphertexts of another scheme
+	forged := NewEncryptedVec(NewPaillierHE(pk, nil), EncryptWith(aBig, dj).Coords)
+	if _, err := DecryptParallel(ctx, forged, sk, 2); err != ErrCiphertextType {
+		t.Fatalf("Expected ErrCiphertextType, got %v", err)
+	}
+}
+
+func TestEncryptedOpsParallel(t *testing.T) {
+
+	pk, sk := paillier.KeyGen(keyBits)
+	ctx := context.Background()
+
+	aBig := NewBigRandomVec(dim, gmp.NewInt(-1000), gmp.NewInt(1000))
+	bBig := NewBigRandomVec(dim, gmp.NewInt(-1000), gmp.NewInt(1000))
+	encA := Encrypt(aBig, pk)
+	encB := Encrypt(bBig, pk)
+
+	sum, err := encA.AddParallel(ctx, encB, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	serialSum, _ := encA.Add(encB)
+	for i := range sum.Coords {
+		if mustPaillierCiphertext(sum.Coords[i]).C.Cmp(mustPaillierCiphertext(serialSum.Coords[i]).C) != 0 {
+			t.Fatalf("Parallel sum differs from serial sum at coordinate %v", i)
+		}
+	}
+
+	diff, err := encA.SubParallel(ctx, encB, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	serialDiff, _ := encA.Sub(encB)
+	for i := range diff.Coords {
+		if mustPaillierCiphertext(diff.Coords[i]).C.Cmp(mustPaillierCiphertext(serialDiff.Coords[i]).C) != 0 {
+			t.Fatalf("Parallel difference differs from serial difference at coordinate %v", i)
+		}
+	}
+
+	dot, err := encA.DotParallel(ctx, bBig, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	serialDot, _ := encA.Dot(bBig)
+	if sk.Decrypt(mustPaillierCiphertext(dot)).Cmp(sk.Decrypt(mustPaillierCiphertext(serialDot))) != 0 {
+		t.Fatalf("Parallel dot product differs from serial dot product")
+	}
+
+	if _, err := encA.AddParallel(ctx, Encrypt(NewBigZeroVec(1), pk), 4); err == nil {
+		t.Fatalf("Expected error for different sized vectors")
+	}
+}
+
+func TestParallelCancel(t *testing.T) {
+
+	pk, _ := paillier.KeyGen(keyBits)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	aBig := NewBigRandomVec(dim, gmp.NewInt(-1000), gmp.NewInt(1000))
+	if _, err := EncryptParallel(ctx, aBig, pk, 2); err != context.Canceled {
+		t.Fatalf("Expected context.Canceled, got %v", err)
+	}
+}
+
+func BenchmarkEncrypt(b *testing.B) {
+
+	pk, _ := paillier.KeyGen(2048)
+	aBig := NewBigRandomVec(64, gmp.NewInt(-1000), gmp.NewInt(1000))
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		Encrypt(aBig, pk)
+	}
+}
+
+func BenchmarkEncryptParallel(b *testing.B) {
+
+	pk, _ := paillier.KeyGen(2048)
+	aBig := NewBigRandomVec(64, gmp.NewInt(-1000), gmp.NewInt(1000))
+	ctx := context.Background()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		EncryptParallel(ctx, aBig, pk, 0)
+	}
+}
+
+func BenchmarkDot(b *testing.B) {
+
+	pk, _ := paillier.KeyGen(2048)
+	aBig := NewBigRandomVec(64, gmp.NewInt(-1000), gmp.NewInt(1000))
+	encA := Encrypt(aBig, pk)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		encA.Dot(aBig)
+	}
+}
+
+func BenchmarkDotParallel(b *testing.B) {
+
+	pk, _ := paillier.KeyGen(2048)
+	aBig := NewBigRandomVec(64, gmp.NewInt(-1000), gmp.NewInt(1000))
+	encA := Encrypt(aBig, pk)
+	ctx := context.Background()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		encA.DotParallel(ctx, aBig, 0)
+	}
+}
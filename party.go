@@ -0,0 +1,218 @@
+package vec
+
+import (
+	"errors"
+
+	"github.com/ncw/gmp"
+)
+
+// Party is a participant in a protocol on secret shared vectors
+// where the ID of the party matches the Index of its shares
+type Party struct {
+	ID         int
+	NumParties int
+	Transport  Transport
+}
+
+// NewParty returns a party communicating with the other parties over the transport
+func NewParty(id int, numParties int, transport Transport) *Party {
+	return &Party{
+		ID:         id,
+		NumParties: numParties,
+		Transport:  transport,
+	}
+}
+
+// Send sends the vector to the party
+func (p *Party) Send(to int, v *BigVec) error {
+
+	msg, err := v.MarshalBinary()
+	if err != nil {
+		return err
+	}
+
+	return p.Transport.Send(to, msg)
+}
+
+// Receive returns the next vector sent by the party
+func (p *Party) Receive(from int) (*BigVec, error) {
+
+	msg, err := p.Transport.Receive(from)
+	if err != nil {
+		return nil, err
+	}
+
+	v := &BigVec{}
+	if err := v.UnmarshalBinary(msg); err != nil {
+		return nil, err
+	}
+
+	return v, nil
+}
+
+// Broadcast sends the vector to all other parties
+func (p *Party) Broadcast(v *BigVec) error {
+
+	msg, err := v.MarshalBinary()
+	if err != nil {
+		return err
+	}
+
+	for to := 0; to < p.NumParties; to++ {
+		if to == p.ID {
+			continue
+		}
+
+		if err := p.Transport.Send(to, msg); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Exchange sends the vector to all other parties and returns
+// the vectors received from every party indexed by party ID
+// (including the party's own vector)
+func (p *Party) Exchange(v *BigVec) ([]*BigVec, error) {
+
+	msg, err := v.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+
+	msgs, err := p.exchangeBytes(msg)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*BigVec, p.NumParties)
+	res[p.ID] = v
+
+	for from, received := range msgs {
+		if from == p.ID {
+			continue
+		}
+
+		res[from] = &BigVec{}
+		if err := res[from].UnmarshalBinary(received); err != nil {
+			return nil, err
+		}
+	}
+
+	return res, nil
+}
+
+// Open reveals the vector secret shared among all parties
+// with coordinates in [0, p) like OpenVector (see BigVec.DecodeSignedValues)
+func (p *Party) Open(share *ShareVec) (*BigVec, error) {
+
+	if share.Index != p.ID {
+		return nil, errors.New("index of share does not match party ID")
+	}
+
+	vectors, err := p.Exchange(share.Vec)
+	if err != nil {
+		return nil, err
+	}
+
+	shares := make([]*ShareVec, len(vectors))
+	for i, v := range vectors {
+		if v.Size() != share.Vec.Size() {
+			return nil, errors.New("received share of a different size")
+		}
+
+		shares[i] = &ShareVec{v, share.P, i}
+	}
+
+	return OpenVector(shares...)
+}
+
+// Mul returns the party's share of the component-wise product of x and y
+// using the party's share of a multiplication triple
+func (p *Party) Mul(x *ShareVec, y *ShareVec, triple *BeaverTriple) (*ShareVec, error) {
+
+	d, e, err := p.openBeaverMasks(x, y, triple)
+	if err != nil {
+		return nil, err
+	}
+
+	return BeaverMul(triple, d, e)
+}
+
+// Dot returns the party's share of the dot product of x and y
+// using the party's share of a multiplication triple
+func (p *Party) Dot(x *ShareVec, y *ShareVec, triple *BeaverTriple) (*gmp.Int, error) {
+
+	d, e, err := p.openBeaverMasks(x, y, triple)
+	if err != nil {
+		return nil, err
+	}
+
+	return BeaverDot(triple, d, e)
+}
+
+// openBeaverMasks opens d = x - a and e = y - b among all parties
+func (p *Party) openBeaverMasks(x *ShareVec, y *ShareVec, triple *BeaverTriple) (*BigVec, *BigVec, error) {
+
+	shareD, shareE, err := BeaverMask(x, y, triple)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	d, err := p.Open(shareD)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	e, err := p.Open(shareE)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return d, e, nil
+}
+
+// exchangeBytes sends the message to all other parties and returns
+// the messages received from every party indexed by party ID
+func (p *Party) exchangeBytes(msg []byte) ([][]byte, error) {
+
+	// send concurrently so that parties exchanging large
+	// messages do not block on each other
+	sent := make(chan error, 1)
+	go func() {
+		for to := 0; to < p.NumParties; to++ {
+			if to == p.ID {
+				continue
+			}
+
+			if err := p.Transport.Send(to, msg); err != nil {
+				sent <- err
+				return
+			}
+		}
+		sent <- nil
+	}()
+
+	res := make([][]byte, p.NumParties)
+	res[p.ID] = msg
+
+	for from := 0; from < p.NumParties; from++ {
+		if from == p.ID {
+			continue
+		}
+
+		received, err := p.Transport.Receive(from)
+		if err != nil {
+			return nil, err
+		}
+
+		res[from] = received
+	}
+
+	if err := <-sent; err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
@@ -0,0 +1,160 @@
+package vec
+
+import (
+	"net"
+	"testing"
+
+	"github.com/ncw/gmp"
+)
+
+// runParties runs the protocol for each party in its own goroutine
+func runParties(t *testing.T, transports []Transport, protocol func(party *Party) error) {
+
+	errs := make(chan error, len(transports))
+	for i, transport := range transports {
+		go func(party *Party) {
+			errs <- protocol(party)
+		}(NewParty(i, len(transports), transport))
+	}
+
+	for range transports {
+		if err := <-errs; err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func memoryTransports(numParties int) []Transport {
+	transports := make([]Transport, numParties)
+	for i, transport := range NewMemoryTransports(numParties) {
+		transports[i] = transport
+	}
+	return transports
+}
+
+func tcpTransports(t *testing.T, numParties int) []Transport {
+
+	listeners := make([]net.Listener, numParties)
+	addrs := make([]string, numParties)
+	for i := 0; i < numParties; i++ {
+		ln, err := net.Listen("tcp", "127.0.0.1:0")
+		if err != nil {
+			t.Fatal(err)
+		}
+		listeners[i] = ln
+		addrs[i] = ln.Addr().String()
+	}
+
+	transports := make([]Transport, numParties)
+	errs := make(chan error, numParties)
+	for i := 0; i < numParties; i++ {
+		go func(i int) {
+			transport, err := NewTCPTransport(i, listeners[i], addrs)
+			transports[i] = transport
+			errs <- err
+		}(i)
+	}
+
+	for i := 0; i < numParties; i++ {
+		if err := <-errs; err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return transports
+}
+
+func TestPartyOpen(t *testing.T) {
+
+	field := randomPrime(100)
+
+	for _, transports := range [][]Transport{memoryTransports(3), tcpTransports(t, 3)} {
+		for trial := 0; trial < 10; trial++ {
+
+			aBig := NewBigRandomVec(dim, gmp.NewInt(-1000), gmp.NewInt(1000))
+			shares := SecretShare(aBig, 3, field)
+
+			runParties(t, transports, func(party *Party) error {
+				res, err := party.Open(shares[party.ID])
+				if err != nil {
+					return err
+				}
+
+				res = res.DecodeSignedValues(field)
+
+				if !res.Equal(aBig) {
+					t.Errorf("Incorrest result. \nExpected %v \nGot %v", aBig, res)
+				}
+
+				return nil
+			})
+		}
+
+		for _, transport := range transports {
+			transport.Close()
+		}
+	}
+}
+
+func TestPartyMulDot(t *testing.T) {
+
+	field := randomPrime(100)
+
+	for _, transports := range [][]Transport{memoryTransports(3), tcpTransports(t, 3)} {
+		for trial := 0; trial < 10; trial++ {
+
+			aBig := NewBigRandomVec(dim, gmp.NewInt(-1000), gmp.NewInt(1000))
+			bBig := NewBigRandomVec(dim, gmp.NewInt(-1000), gmp.NewInt(1000))
+			sharesA := SecretShare(aBig, 3, field)
+			sharesB := SecretShare(bBig, 3, field)
+			triples := NewBeaverTriples(dim, 3, field)
+			dotTriples := NewBeaverTriples(dim, 3, field)
+
+			expectedProd, _ := aBig.Clone().Mul(bBig)
+			expectedDot, _ := aBig.Dot(bBig)
+
+			runParties(t, transports, func(party *Party) error {
+				prod, err := party.Mul(sharesA[party.ID], sharesB[party.ID], triples[party.ID])
+				if err != nil {
+					return err
+				}
+
+				res, err := party.Open(prod)
+				if err != nil {
+					return err
+				}
+
+				res = res.DecodeSignedValues(field)
+
+				if !res.Equal(expectedProd) {
+					t.Errorf("Incorrest result. \nExpected %v \nGot %v", expectedProd, res)
+				}
+
+				dot, err := party.Dot(sharesA[party.ID], sharesB[party.ID], dotTriples[party.ID])
+				if err != nil {
+					return err
+				}
+
+				dots, err := party.Exchange(NewBigVec([]*gmp.Int{dot}))
+				if err != nil {
+					return err
+				}
+
+				shares := make([]*gmp.Int, len(dots))
+				for i, v := range dots {
+					shares[i] = v.Coord(0)
+				}
+
+				if got := RecoverInt(field, shares...); got.Cmp(expectedDot) != 0 {
+					t.Errorf("Incorrest result. Expected %v, got %v", expectedDot, got)
+				}
+
+				return nil
+			})
+		}
+
+		for _, transport := range transports {
+			transport.Close()
+		}
+	}
+}
@@ -0,0 +1,227 @@
+package vec
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"sync"
+
+	"github.com/ncw/gmp"
+	"github.com/sachaservan/paillier"
+)
+
+// ErrPoolClosed is returned when taking a randomizer from a closed and empty pool
+var ErrPoolClosed = errors.New("randomness pool is closed")
+
+// RandomnessPool precomputes Paillier randomizers r^n mod n^2 in the background
+// so that online encryption only requires a multiplication.
+// Every randomizer is handed out exactly once and never reused
+type RandomnessPool struct {
+	pk          *paillier.PublicKey
+	nSquared    *gmp.Int
+	randomizers chan *gmp.Int
+	done        chan struct{}
+	wg          sync.WaitGroup
+	closeOnce   sync.Once
+}
+
+// NewRandomnessPool starts precomputing up to size randomizers for pk using
+// the given number of background workers (one per CPU if workers <= 0)
+func NewRandomnessPool(pk *paillier.PublicKey, size int, workers int) *RandomnessPool {
+
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+
+	pool := &RandomnessPool{
+		pk:          pk,
+		nSquared:    new(gmp.Int).Mul(pk.N, pk.N),
+		randomizers: make(chan *gmp.Int, size),
+		done:        make(chan struct{}),
+	}
+
+	for w := 0; w < workers; w++ {
+		pool.wg.Add(1)
+		go pool.fill()
+	}
+
+	return pool
+}
+
+// NewPaillierHEWithPool returns the Paillier scheme for the keys of the pool (sk may be nil)
+// which draws the randomness of encryptions and rerandomizations from the pool
+// and falls back to fresh randomness once the pool is closed and empty
+func NewPaillierHEWithPool(pool *RandomnessPool, sk *paillier.SecretKey) *PaillierHE {
+	return &PaillierHE{
+		Pk:   pool.pk,
+		Sk:   sk,
+		Pool: pool,
+	}
+}
+
+// PublicKey returns the public key the randomizers are computed for
+func (pool *RandomnessPool) PublicKey() *paillier.PublicKey {
+	return pool.pk
+}
+
+// Available returns the number of precomputed randomizers
+func (pool *RandomnessPool) Available() int {
+	return len(pool.randomizers)
+}
+
+// Next removes a randomizer from the pool, waiting for one to be
+// computed if the pool is empty
+func (pool *RandomnessPool) Next(ctx context.Context) (*gmp.Int, error) {
+
+	// drain precomputed randomizers even if the pool is closed
+	select {
+	case r := <-pool.randomizers:
+		return r, nil
+	default:
+	}
+
+	select {
+	case r := <-pool.randomizers:
+		return r, nil
+	case <-pool.done:
+		return nil, ErrPoolClosed
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// Encrypt returns an encryption of m using a precomputed randomizer
+func (pool *RandomnessPool) Encrypt(ctx context.Context, m *gmp.Int) (Ciphertext, error) {
+
+	r, err := pool.Next(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	c := generatorPow(pool.pk, m, pool.nSquared)
+	c.Mul(c, r)
+	c.Mod(c, pool.nSquared)
+
+	return &paillier.Ciphertext{C: c}, nil
+}
+
+// generatorPow returns g^m mod n^2 for the generator g of pk
+// which is computed as 1 + m*n mod n^2 when g = 1 + n
+func generatorPow(pk *paillier.PublicKey, m *gmp.Int, nSquared *gmp.Int) *gmp.Int {
+
+	c := new(gmp.Int).Mod(m, pk.N)
+
+	if pk.G.Cmp(new(gmp.Int).Add(pk.N, gmp.NewInt(1))) != 0 {
+		return c.Exp(pk.G, c, nSquared)
+	}
+
+	// (1 + n)^m = 1 + m*n mod n^2
+	c.Mul(c, pk.N)
+	c.Add(c, gmp.NewInt(1))
+
+	return c.Mod(c, nSquared)
+}
+
+// EncryptZero returns an encryption of zero using a precomputed randomizer
+func (pool *RandomnessPool) EncryptZero(ctx context.Context) (Ciphertext, error) {
+
+	r, err := pool.Next(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &paillier.Ciphertext{C: r}, nil
+}
+
+// Rerandomize returns a fresh encryption of the same plaintext as ct
+// using a precomputed randomizer
+func (pool *RandomnessPool) Rerandomize(ctx context.Context, ct Ciphertext) (Ciphertext, error) {
+
+	c, err := paillierCiphertext(ct)
+	if err != nil {
+		return nil, err
+	}
+
+	r, err := pool.Next(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return pool.pk.Add(c, &paillier.Ciphertext{C: r}), nil
+}
+
+// Close stops the background workers; randomizers that
+// were already computed can still be used
+func (pool *RandomnessPool) Close() {
+	pool.closeOnce.Do(func() {
+		close(pool.done)
+	})
+	pool.wg.Wait()
+}
+
+// fill computes randomizers until the pool is closed
+func (pool *RandomnessPool) fill() {
+
+	defer pool.wg.Done()
+
+	for {
+		r := pool.newRandomizer()
+
+		select {
+		case pool.randomizers <- r:
+		case <-pool.done:
+			return
+		}
+	}
+}
+
+// newRandomizer returns r^n mod n^2 for a fresh random r in Z_n^*
+func (pool *RandomnessPool) newRandomizer() *gmp.Int {
+
+	one := gmp.NewInt(1)
+	gcd := new(gmp.Int)
+
+	for {
+		r := newCryptoRandom(pool.pk.N)
+		if r.Sign() > 0 && gcd.GCD(nil, nil, r, pool.pk.N).Cmp(one) == 0 {
+			return r.Exp(r, pool.pk.N, pool.nSquared)
+		}
+	}
+}
+
+// EncryptWithPool returns an encryption of the vector using
+// precomputed randomizers from the pool
+func EncryptWithPool(ctx context.Context, a *BigVec, pool *RandomnessPool) (*EncryptedVec, error) {
+
+	encrypted := make([]Ciphertext, len(a.Coords))
+
+	for i, coord := range a.Coords {
+		var err error
+		if encrypted[i], err = pool.Encrypt(ctx, coord); err != nil {
+			return nil, err
+		}
+	}
+
+	return NewEncryptedVec(NewPaillierHE(pool.pk, nil), encrypted), nil
+}
+
+// RerandomizeWithPool returns a fresh encryption of the same vector as a
+// using precomputed randomizers from the pool
+// throws ErrSchemeMismatch if a is not encrypted under the key of the pool
+func (a *EncryptedVec) RerandomizeWithPool(ctx context.Context, pool *RandomnessPool) (*EncryptedVec, error) {
+
+	if !a.Scheme.Equal(NewPaillierHE(pool.pk, nil)) {
+		return nil, ErrSchemeMismatch
+	}
+
+	res := make([]Ciphertext, len(a.Coords))
+
+	for i := range a.Coords {
+		var err error
+		if res[i], err = pool.Rerandomize(ctx, a.Coords[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	return NewEncryptedVec(a.Scheme, res), nil
+}
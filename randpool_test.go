@@ -0,0 +1,168 @@
+package vec
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ncw/gmp"
+	"github.com/sachaservan/paillier"
+)
+
+func TestEncryptWithPool(t *testing.T) {
+
+	pk, sk := paillier.KeyGen(keyBits)
+	pool := NewRandomnessPool(pk, 2*dim, 2)
+	defer pool.Close()
+
+	ctx := context.Background()
+
+	for trial := 0; trial < 10; trial++ {
+
+		aBig := NewBigRandomVec(dim, gmp.NewInt(-1000), gmp.NewInt(1000))
+
+		encA, err := EncryptWithPool(ctx, aBig, pool)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		res, err := DecryptSigned(encA, sk)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !res.Equal(aBig) {
+			t.Fatalf("Incorrest result. \nExpected %v \nGot %v", aBig, res)
+		}
+
+		zero, err := pool.EncryptZero(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if res := sk.Decrypt(mustPaillierCiphertext(zero)); res.Sign() != 0 {
+			t.Fatalf("Incorrest result. Expected 0, got %v", res)
+		}
+	}
+}
+
+func TestRandomnessPoolNoReuse(t *testing.T) {
+
+	pk, _ := paillier.KeyGen(keyBits)
+	pool := NewRandomnessPool(pk, 10, 2)
+	ctx := context.Background()
+
+	seen := make(map[string]bool)
+	for i := 0; i < 50; i++ {
+		r, err := pool.Next(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if seen[r.String()] {
+			t.Fatalf("Randomizer was reused")
+		}
+		seen[r.String()] = true
+	}
+
+	pool.Close()
+
+	// remaining precomputed randomizers can be used but none are added
+	for i := 0; i < 11; i++ {
+		if _, err := pool.Next(ctx); err == ErrPoolClosed {
+			return
+		}
+	}
+
+	t.Fatalf("Expected closed pool to run out of randomizers")
+}
+
+func TestRerandomizeWithPool(t *testing.T) {
+
+	pk, sk := paillier.KeyGen(keyBits)
+	pool := NewRandomnessPool(pk, dim, 2)
+	defer pool.Close()
+
+	ctx := context.Background()
+
+	for trial := 0; trial < 10; trial++ {
+
+		aBig := NewBigRandomVec(dim, gmp.NewInt(-1000), gmp.NewInt(1000))
+		encA := Encrypt(aBig, pk)
+
+		res, err := encA.RerandomizeWithPool(ctx, pool)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for i := range encA.Coords {
+			if mustPaillierCiphertext(encA.Coords[i]).C.Cmp(mustPaillierCiphertext(res.Coords[i]).C) == 0 {
+				t.Fatalf("Rerandomized ciphertext equals the original at coordinate %v", i)
+			}
+		}
+
+		got, err := DecryptSigned(res, sk)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !got.Equal(aBig) {
+			t.Fatalf("Incorrest result. \nExpected %v \nGot %v", aBig, got)
+		}
+	}
+
+	otherPk, _ := paillier.KeyGen(keyBits)
+	encOther := Encrypt(NewBigRandomVec(dim, gmp.NewInt(-1000), gmp.NewInt(1000)), otherPk)
+	if _, err := encOther.RerandomizeWithPool(ctx, pool); err != ErrSchemeMismatch {
+		t.Fatalf("Expected ErrSchemeMismatch, got %v", err)
+	}
+}
+
+func TestRerandomizeAttachedPool(t *testing.T) {
+
+	pk, sk := paillier.KeyGen(keyBits)
+	pool := NewRandomnessPool(pk, dim, 2)
+	scheme := NewPaillierHEWithPool(pool, sk)
+
+	aBig := NewBigRandomVec(dim, gmp.NewInt(-1000), gmp.NewInt(1000))
+	encA := EncryptWith(aBig, scheme)
+
+	// once the pool is closed and drained fresh randomness is used
+	pool.Close()
+	for trial := 0; trial < 3; trial++ {
+
+		res := encA.Rerandomize()
+		for i := range encA.Coords {
+			if mustPaillierCiphertext(encA.Coords[i]).C.Cmp(mustPaillierCiphertext(res.Coords[i]).C) == 0 {
+				t.Fatalf("Rerandomized ciphertext equals the original at coordinate %v", i)
+			}
+		}
+
+		got, err := DecryptWith(res, scheme)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if got = got.DecodeSignedValues(pk.N); !got.Equal(aBig) {
+			t.Fatalf("Incorrest result. \nExpected %v \nGot %v", aBig, got)
+		}
+	}
+
+	if pool.Available() != 0 {
+		t.Fatalf("Expected rerandomization to draw from the pool")
+	}
+}
+
+func TestGeneratorPow(t *testing.T) {
+
+	pk, _ := paillier.KeyGen(keyBits)
+	nSquared := new(gmp.Int).Mul(pk.N, pk.N)
+
+	for trial := 0; trial < 100; trial++ {
+		m := NewBigRandomVec(1, gmp.NewInt(-1000000), gmp.NewInt(1000000)).Coords[0]
+
+		expected := new(gmp.Int).Exp(pk.G, new(gmp.Int).Mod(m, pk.N), nSquared)
+		if got := generatorPow(pk, m, nSquared); got.Cmp(expected) != 0 {
+			t.Fatalf("Incorrest result. Expected %v, got %v", expected, got)
+		}
+	}
+}
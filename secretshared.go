@@ -14,6 +14,7 @@ type ShareVec struct {
 }
 
 // SecretShare returns secret shares of the vector where p is a prime modulus
+// (see FixedPoint.SecretShare for vectors of floats)
 func SecretShare(a *BigVec, numShares int, p *gmp.Int) []*ShareVec {
 
 	// run 20 tests of Rabin-Miller
@@ -70,6 +71,7 @@ func (a *ShareVec) SetCoords(v *BigVec) {
 }
 
 // RecoverVector outputs the recovered BigVec from the set of secret shares
+// (see FixedPoint.RecoverVector for vectors of floats)
 func RecoverVector(shares ...*ShareVec) (*BigVec, error) {
 
 	dim := len(shares[0].Vec.Coords)
@@ -89,12 +91,17 @@ func RecoverVector(shares ...*ShareVec) (*BigVec, error) {
 }
 
 // RecoverInt returns a an integer encoded in the shares
+// where the shares are reduced mod p before decoding the sign
 func RecoverInt(p *gmp.Int, shares ...*gmp.Int) *gmp.Int {
 	res := new(gmp.Int)
 	for _, share := range shares {
 		res.Add(res, share)
 	}
 
+	// the sum of n shares in [0, p) can be as large as n*(p-1)
+	// so it has to be reduced before it can be decoded as a signed value
+	res.Mod(res, p)
+
 	// decode the sign
 	negThresh := new(gmp.Int).Quo(p, gmp.NewInt(2))
 	if res.Cmp(negThresh) > 0 {
@@ -158,6 +165,25 @@ func (a *ShareVec) Mul(b *BigVec) (*ShareVec, error) {
 	return &ShareVec{c, a.P, a.Index}, nil
 }
 
+// SubPublic returns the component-wise subtaction of the public vector b from
+// the additive share a (see SecretShare) where only the party with index 0
+// subtracts b from its share (see SubPublicShamir for Shamir shares)
+func (a *ShareVec) SubPublic(b *BigVec) (*ShareVec, error) {
+
+	if len(a.Vec.Coords) != len(b.Coords) {
+		return nil, errors.New("cannot subtract different sized vectors")
+	}
+
+	c := a.Vec.Clone()
+	if a.Index == 0 {
+		c.Sub(b)
+	}
+
+	c = c.Mod(a.P)
+
+	return &ShareVec{c, a.P, a.Index}, nil
+}
+
 // Dot returns the (encrypted) dot product of the two vectors a and b
 // using the homomorphic encryption property of the encrypted vector
 func (a *ShareVec) Dot(b *BigVec) (*gmp.Int, error) {
@@ -148,3 +148,23 @@ func randomPrime(bits int) *gmp.Int {
 		}
 	}
 }
+
+func TestRecoverInt(t *testing.T) {
+
+	field := randomPrime(64)
+
+	for trial := 0; trial < 100; trial++ {
+		aBig := NewBigRandomVec(1, gmp.NewInt(-1000), gmp.NewInt(1000))
+
+		// with 5 shares the sum of the shares is almost always larger than p
+		shares := SecretShare(aBig, 5, field)
+		coords := make([]*gmp.Int, len(shares))
+		for i, share := range shares {
+			coords[i] = share.Vec.Coords[0]
+		}
+
+		if res := RecoverInt(field, coords...); res.Cmp(aBig.Coords[0]) != 0 {
+			t.Fatalf("Incorrest result. Expected %v, got %v", aBig.Coords[0], res)
+		}
+	}
+}
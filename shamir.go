@@ -0,0 +1,173 @@
+package vec
+
+import (
+	"errors"
+
+	"github.com/ncw/gmp"
+)
+
+// ShamirShare returns t-out-of-n Shamir secret shares of the vector where p is a prime modulus.
+// The Index of each share is its (non-zero) evaluation point.
+// The linear operations on ShareVec (Add, Sub, Mul and Dot with a public vector)
+// remain valid on Shamir shares and preserve the threshold,
+// but public vectors must be subtracted with SubPublicShamir instead of SubPublic
+func ShamirShare(a *BigVec, numShares int, threshold int, p *gmp.Int) []*ShareVec {
+
+	// run 20 tests of Rabin-Miller
+	if !p.ProbablyPrime(20) {
+		panic("trying to secret share in a non-prime order field")
+	}
+
+	if threshold < 1 || threshold > numShares {
+		panic("incorrect threshold provided: must be between 1 and the number of shares")
+	}
+
+	if p.Cmp(gmp.NewInt(int64(numShares))) <= 0 {
+		panic("field is too small for the number of shares")
+	}
+
+	dim := len(a.Coords)
+
+	// coefficients of the random polynomials of degree threshold-1
+	// with the secret as the constant term
+	coeffs := make([]*BigVec, threshold)
+	coeffs[0] = a.Clone().Mod(p)
+	for k := 1; k < threshold; k++ {
+		coeffs[k] = NewBigRandomVec(dim, gmp.NewInt(0), p)
+	}
+
+	shares := make([]*ShareVec, numShares)
+	for i := 0; i < numShares; i++ {
+		x := gmp.NewInt(int64(i + 1))
+		y := NewBigZeroVec(dim)
+
+		// evaluate the polynomials at x using Horner's method
+		for k := threshold - 1; k >= 0; k-- {
+			for j := 0; j < dim; j++ {
+				y.Coords[j].Mul(y.Coords[j], x)
+				y.Coords[j].Add(y.Coords[j], coeffs[k].Coords[j])
+				y.Coords[j].Mod(y.Coords[j], p)
+			}
+		}
+
+		shares[i] = &ShareVec{y, p, i + 1}
+	}
+
+	return shares
+}
+
+// SubPublicShamir returns the component-wise subtaction of the public vector b
+// from the Shamir share a where every share subtracts b
+// (shifting the constant term of the sharing polynomial)
+func (a *ShareVec) SubPublicShamir(b *BigVec) (*ShareVec, error) {
+
+	if len(a.Vec.Coords) != len(b.Coords) {
+		return nil, errors.New("cannot subtract different sized vectors")
+	}
+
+	c, _ := a.Vec.Clone().Sub(b)
+	c = c.Mod(a.P)
+
+	return &ShareVec{c, a.P, a.Index}, nil
+}
+
+// RecoverShamirVector outputs the recovered BigVec from a set of Shamir secret shares
+// using Lagrange interpolation; any threshold many shares suffice
+func RecoverShamirVector(shares ...*ShareVec) (*BigVec, error) {
+
+	if len(shares) == 0 {
+		return nil, errors.New("no shares provided")
+	}
+
+	dim := len(shares[0].Vec.Coords)
+	p := shares[0].P
+
+	indices := make([]int, len(shares))
+	for i, share := range shares {
+		if len(share.Vec.Coords) != dim {
+			return nil, errors.New("cannot recover from different sized shares")
+		}
+
+		if share.P.Cmp(p) != 0 {
+			return nil, errors.New("cannot recover from shares in different fields")
+		}
+
+		indices[i] = share.Index
+	}
+
+	lambdas, err := lagrangeCoefficients(p, indices)
+	if err != nil {
+		return nil, err
+	}
+
+	res := NewBigZeroVec(dim)
+	for i, share := range shares {
+		for j := 0; j < dim; j++ {
+			term := new(gmp.Int).Mul(share.Vec.Coords[j], lambdas[i])
+			res.Coords[j].Add(res.Coords[j], term)
+		}
+	}
+
+	res = res.Mod(p)
+	res = res.DecodeSignedValues(p)
+
+	return res, nil
+}
+
+// RecoverShamirInt returns an integer encoded in Shamir shares
+// where indices are the evaluation points of the shares
+func RecoverShamirInt(p *gmp.Int, indices []int, shares ...*gmp.Int) (*gmp.Int, error) {
+
+	if len(indices) != len(shares) {
+		return nil, errors.New("number of indices does not match number of shares")
+	}
+
+	lambdas, err := lagrangeCoefficients(p, indices)
+	if err != nil {
+		return nil, err
+	}
+
+	res := gmp.NewInt(0)
+	for i, share := range shares {
+		res.Add(res, new(gmp.Int).Mul(share, lambdas[i]))
+	}
+
+	res.Mod(res, p)
+
+	return RecoverInt(p, res), nil
+}
+
+// lagrangeCoefficients returns the Lagrange coefficients for
+// interpolating at zero from the evaluation points
+func lagrangeCoefficients(p *gmp.Int, indices []int) ([]*gmp.Int, error) {
+
+	lambdas := make([]*gmp.Int, len(indices))
+	for i, xi := range indices {
+		if xi <= 0 {
+			return nil, errors.New("evaluation points must be non-zero")
+		}
+
+		num := gmp.NewInt(1)
+		den := gmp.NewInt(1)
+		for j, xj := range indices {
+			if i == j {
+				continue
+			}
+
+			if xi == xj {
+				return nil, errors.New("duplicate evaluation points")
+			}
+
+			num.Mul(num, gmp.NewInt(int64(xj)))
+			num.Mod(num, p)
+			den.Mul(den, gmp.NewInt(int64(xj-xi)))
+			den.Mod(den, p)
+		}
+
+		den.ModInverse(den, p)
+		lambdas[i] = num.Mul(num, den)
+		lambdas[i].Mod(lambdas[i], p)
+	}
+
+	return lambdas, nil
+}
@@ -0,0 +1,107 @@
+package vec
+
+import (
+	"testing"
+
+	"github.com/ncw/gmp"
+)
+
+func TestShamirShare(t *testing.T) {
+
+	field := randomPrime(100)
+
+	for trial := 0; trial < 100; trial++ {
+
+		aBig := NewBigRandomVec(dim, gmp.NewInt(-1000), gmp.NewInt(1000))
+		shares := ShamirShare(aBig, 5, 3, field)
+
+		// any 3 of the 5 shares recover the vector
+		subsets := [][]*ShareVec{
+			{shares[0], shares[1], shares[2]},
+			{shares[4], shares[1], shares[3]},
+			{shares[0], shares[2], shares[3], shares[4]},
+		}
+
+		for _, subset := range subsets {
+			recovered, err := RecoverShamirVector(subset...)
+			if err != nil || !recovered.Equal(aBig) {
+				t.Fatalf("Incorrest result. Expected %v got %v", aBig, recovered)
+			}
+		}
+
+		// 2 shares do not recover the vector
+		recovered, err := RecoverShamirVector(shares[0], shares[1])
+		if err != nil || recovered.Equal(aBig) {
+			t.Fatalf("Recovered vector from fewer than threshold shares")
+		}
+	}
+}
+
+func TestShamirShareLinear(t *testing.T) {
+
+	field := randomPrime(100)
+
+	for trial := 0; trial < 100; trial++ {
+
+		aBig := NewBigRandomVec(dim, gmp.NewInt(-100), gmp.NewInt(100))
+		bBig := NewBigRandomVec(dim, gmp.NewInt(-100), gmp.NewInt(100))
+		cBig := NewBigRandomVec(dim, gmp.NewInt(-100), gmp.NewInt(100))
+		sharesA := ShamirShare(aBig, 4, 2, field)
+		sharesB := ShamirShare(bBig, 4, 2, field)
+
+		sums := make([]*ShareVec, 2)
+		diffs := make([]*ShareVec, 2)
+		prods := make([]*ShareVec, 2)
+		dots := make([]*gmp.Int, 2)
+		indices := make([]int, 2)
+		for i, j := range []int{1, 3} {
+			sum, err := sharesA[j].Add(sharesB[j])
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			sums[i] = &ShareVec{sum.Vec.Clone(), field, sum.Index}
+
+			diffs[i], err = sum.SubPublicShamir(cBig)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			prods[i], err = sum.Mul(cBig)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			dots[i], err = sums[i].Dot(cBig)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			indices[i] = sharesA[j].Index
+		}
+
+		expectedSum, _ := aBig.Clone().Add(bBig)
+		res, err := RecoverShamirVector(sums...)
+		if err != nil || !res.Equal(expectedSum) {
+			t.Fatalf("Incorrest result. \nExpected %v \nGot %v", expectedSum, res)
+		}
+
+		expectedDiff, _ := expectedSum.Clone().Sub(cBig)
+		res, err = RecoverShamirVector(diffs...)
+		if err != nil || !res.Equal(expectedDiff) {
+			t.Fatalf("Incorrest result. \nExpected %v \nGot %v", expectedDiff, res)
+		}
+
+		expectedProd, _ := expectedSum.Clone().Mul(cBig)
+		res, err = RecoverShamirVector(prods...)
+		if err != nil || !res.Equal(expectedProd) {
+			t.Fatalf("Incorrest result. \nExpected %v \nGot %v", expectedProd, res)
+		}
+
+		expectedDot, _ := expectedSum.Dot(cBig)
+		got, err := RecoverShamirInt(field, indices, dots...)
+		if err != nil || got.Cmp(expectedDot) != 0 {
+			t.Fatalf("Incorrest result. Expected %v, got %v", expectedDot, got)
+		}
+	}
+}
@@ -0,0 +1,123 @@
+package vec
+
+import (
+	"crypto/rand"
+	"errors"
+	"math/big"
+)
+
+// Shuffle returns a rerandomized encryption of a with its coordinates permuted
+// by a uniformly random permutation perm (drawn using crypto/rand)
+// such that res.Coords[i] is an encryption of the plaintext of a.Coords[perm[i]]
+func (a *EncryptedVec) Shuffle() (*EncryptedVec, []int, error) {
+
+	perm, err := RandomPermutation(a.Size())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	res, err := a.Permute(perm)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return res.Rerandomize(), perm, nil
+}
+
+// ShuffleJointly applies the same uniformly random permutation to the coordinates
+// of every vector and rerandomizes them so that coordinates stay aligned across vectors
+func ShuffleJointly(vecs []*EncryptedVec) ([]*EncryptedVec, []int, error) {
+
+	if len(vecs) == 0 {
+		return []*EncryptedVec{}, []int{}, nil
+	}
+
+	perm, err := RandomPermutation(vecs[0].Size())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	res := make([]*EncryptedVec, len(vecs))
+	for i, v := range vecs {
+		permuted, err := v.Permute(perm)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		res[i] = permuted.Rerandomize()
+	}
+
+	return res, perm, nil
+}
+
+// ShuffleVecs returns the vectors in a uniformly random order perm
+// and rerandomizes them such that res[i] encrypts the same vector as vecs[perm[i]]
+func ShuffleVecs(vecs []*EncryptedVec) ([]*EncryptedVec, []int, error) {
+
+	perm, err := RandomPermutation(len(vecs))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	res := make([]*EncryptedVec, len(vecs))
+	for i, j := range perm {
+		res[i] = vecs[j].Rerandomize()
+	}
+
+	return res, perm, nil
+}
+
+// Permute returns the vector with coordinates res.Coords[i] = a.Coords[perm[i]]
+// without rerandomizing the ciphertexts
+func (a *EncryptedVec) Permute(perm []int) (*EncryptedVec, error) {
+
+	if len(perm) != len(a.Coords) {
+		return nil, errors.New("permutation size does not match vector size")
+	}
+
+	res := make([]Ciphertext, len(a.Coords))
+	seen := make([]bool, len(a.Coords))
+	for i, j := range perm {
+		if j < 0 || j >= len(a.Coords) || seen[j] {
+			return nil, errors.New("invalid permutation")
+		}
+
+		seen[j] = true
+		res[i] = a.Coords[j]
+	}
+
+	return NewEncryptedVec(a.Scheme, res), nil
+}
+
+// RandomPermutation returns a uniformly random permutation of [0, n)
+// using the Fisher-Yates shuffle with randomness from crypto/rand
+func RandomPermutation(n int) ([]int, error) {
+
+	perm := make([]int, n)
+	for i := range perm {
+		perm[i] = i
+	}
+
+	for i := n - 1; i > 0; i-- {
+		j, err := rand.Int(rand.Reader, big.NewInt(int64(i+1)))
+		if err != nil {
+			return nil, err
+		}
+
+		perm[i], perm[j.Int64()] = perm[j.Int64()], perm[i]
+	}
+
+	return perm, nil
+}
+
+// InvertPermutation returns the inverse permutation inv such
+// that applying perm and then inv gives the identity
+func InvertPermutation(perm []int) []int {
+
+	inv := make([]int, len(perm))
+	for i, j := range perm {
+		inv[j] = i
+	}
+
+	return inv
+}
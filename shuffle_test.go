@@ -0,0 +1,143 @@
+package vec
+
+import (
+	"testing"
+
+	"github.com/ncw/gmp"
+	"github.com/sachaservan/paillier"
+)
+
+func TestRandomPermutation(t *testing.T) {
+
+	numIdentity := 0
+	for trial := 0; trial < 100; trial++ {
+		perm, err := RandomPermutation(dim)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		seen := make([]bool, dim)
+		fixed := 0
+		for i, j := range perm {
+			if seen[j] {
+				t.Fatalf("Not a permutation: %v", perm)
+			}
+			seen[j] = true
+
+			if i == j {
+				fixed++
+			}
+		}
+
+		if fixed == dim {
+			numIdentity++
+		}
+
+		inv := InvertPermutation(perm)
+		for i := range perm {
+			if inv[perm[i]] != i {
+				t.Fatalf("Incorrect inverse permutation")
+			}
+		}
+	}
+
+	if numIdentity > 0 {
+		t.Fatalf("Random permutation is the identity (very unlikely)")
+	}
+}
+
+func TestShuffle(t *testing.T) {
+
+	pk, sk := paillier.KeyGen(keyBits)
+
+	for trial := 0; trial < 5; trial++ {
+
+		aBig := NewBigRandomVec(dim, gmp.NewInt(-1000), gmp.NewInt(1000))
+		encA := Encrypt(aBig, pk)
+
+		res, perm, err := encA.Shuffle()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		got, err := DecryptSigned(res, sk)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for i, j := range perm {
+			if got.Coords[i].Cmp(aBig.Coords[j]) != 0 {
+				t.Fatalf("Incorrest result at coordinate %v. Expected %v, got %v", i, aBig.Coords[j], got.Coords[i])
+			}
+
+			if mustPaillierCiphertext(res.Coords[i]).C.Cmp(mustPaillierCiphertext(encA.Coords[j]).C) == 0 {
+				t.Fatalf("Shuffled ciphertext was not rerandomized")
+			}
+		}
+
+		// the party holding the permutation can undo it
+		unshuffled, err := res.Permute(InvertPermutation(perm))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		got, err = DecryptSigned(unshuffled, sk)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !got.Equal(aBig) {
+			t.Fatalf("Incorrest result. \nExpected %v \nGot %v", aBig, got)
+		}
+	}
+}
+
+func TestShuffleJointlyAndVecs(t *testing.T) {
+
+	pk, sk := paillier.KeyGen(keyBits)
+
+	vecs := make([]*BigVec, 3)
+	encVecs := make([]*EncryptedVec, 3)
+	for i := range vecs {
+		vecs[i] = NewBigRandomVec(dim, gmp.NewInt(-1000), gmp.NewInt(1000))
+		encVecs[i] = Encrypt(vecs[i], pk)
+	}
+
+	res, perm, err := ShuffleJointly(encVecs)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for k := range vecs {
+		got, err := DecryptSigned(res[k], sk)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for i, j := range perm {
+			if got.Coords[i].Cmp(vecs[k].Coords[j]) != 0 {
+				t.Fatalf("Incorrest result for vector %v at coordinate %v", k, i)
+			}
+		}
+	}
+
+	res, perm, err = ShuffleVecs(encVecs)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i, j := range perm {
+		got, err := DecryptSigned(res[i], sk)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !got.Equal(vecs[j]) {
+			t.Fatalf("Incorrest result. \nExpected %v \nGot %v", vecs[j], got)
+		}
+	}
+
+	if _, err := encVecs[0].Permute([]int{0}); err == nil {
+		t.Fatalf("Expected error for invalid permutation")
+	}
+}
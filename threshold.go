@@ -0,0 +1,272 @@
+package vec
+
+import (
+	"crypto/rand"
+	"errors"
+
+	"github.com/ncw/gmp"
+)
+
+// ThresholdPublicKey is the public key of a t-out-of-n threshold Paillier
+// (Damgård–Jurik) scheme where decryption requires the partial
+// decryptions of Threshold of the NumServers decryption servers.
+//
+// Vectors are encrypted with EncryptWith(a, pub.Scheme) and have DamgardJurikCiphertext
+// coordinates, so the helpers that take a paillier key (Encrypt, DecryptToShare,
+// EncryptBinaryWithProof and EncryptRangeWithProof) cannot be used with this key.
+//
+// Servers do not prove that their partial decryptions are correct, so a malicious
+// server can make a combination of exactly Threshold partial decryptions decrypt
+// to an arbitrary value; Combine only detects cheating when given more partial
+// decryptions than Threshold and some of them are honest
+type ThresholdPublicKey struct {
+	Scheme     *DamgardJurikHE // public encryption scheme (without secret key)
+	NumServers int
+	Threshold  int
+}
+
+// ThresholdKeyShare is a decryption server's share of the threshold secret key
+type ThresholdKeyShare struct {
+	PublicKey *ThresholdPublicKey
+	Share     *gmp.Int
+	Index     int // evaluation point of the share (1 to NumServers)
+}
+
+// PartialDecryption is a decryption server's share of the decryption of an encrypted vector
+type PartialDecryption struct {
+	Coords []*gmp.Int
+	Index  int // evaluation point of the key share used
+}
+
+// NewThresholdPaillier generates a threshold Paillier key with a modulus of the given
+// bit length split among numServers decryption servers (trusted dealer)
+// where any threshold many servers can decrypt
+func NewThresholdPaillier(bits int, numServers int, threshold int) (*ThresholdPublicKey, []*ThresholdKeyShare, error) {
+	return NewThresholdDamgardJurik(bits, 1, numServers, threshold)
+}
+
+// NewThresholdDamgardJurik generates a threshold Damgård–Jurik key with plaintext space Z_{n^s}
+// split among numServers decryption servers (trusted dealer) where any threshold many servers can decrypt
+func NewThresholdDamgardJurik(bits int, s int, numServers int, threshold int) (*ThresholdPublicKey, []*ThresholdKeyShare, error) {
+
+	if s < 1 {
+		return nil, nil, errors.New("Damgård–Jurik parameter s must be at least 1")
+	}
+
+	if threshold < 1 || threshold > numServers {
+		return nil, nil, errors.New("incorrect threshold provided: must be between 1 and the number of servers")
+	}
+
+	one := gmp.NewInt(1)
+
+	// n = pq for safe primes p = 2p' + 1 and q = 2q' + 1
+	var n, m *gmp.Int
+	for {
+		p, pp, err := safePrime(bits / 2)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		q, qq, err := safePrime(bits - bits/2)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if p.Cmp(q) == 0 {
+			continue
+		}
+
+		n = new(gmp.Int).Mul(p, q)
+		m = new(gmp.Int).Mul(pp, qq)
+
+		if new(gmp.Int).GCD(nil, nil, n, m).Cmp(one) == 0 {
+			break
+		}
+	}
+
+	pub := &ThresholdPublicKey{
+		Scheme:     newDamgardJurikHE(n, s, nil),
+		NumServers: numServers,
+		Threshold:  threshold,
+	}
+
+	ns := pub.Scheme.PlaintextModulus()
+	nsm := new(gmp.Int).Mul(ns, m)
+
+	// secret d with d = 0 mod m and d = 1 mod n^s
+	d := new(gmp.Int).ModInverse(m, ns)
+	d.Mul(d, m)
+	d.Mod(d, nsm)
+
+	// random polynomial of degree threshold-1 over Z_{n^s * m} with d as the constant term
+	coeffs := make([]*gmp.Int, threshold)
+	coeffs[0] = d
+	for k := 1; k < threshold; k++ {
+		coeffs[k] = newCryptoRandom(nsm)
+	}
+
+	shares := make([]*ThresholdKeyShare, numServers)
+	for i := 0; i < numServers; i++ {
+		x := gmp.NewInt(int64(i + 1))
+		y := gmp.NewInt(0)
+
+		for k := threshold - 1; k >= 0; k-- {
+			y.Mul(y, x)
+			y.Add(y, coeffs[k])
+			y.Mod(y, nsm)
+		}
+
+		shares[i] = &ThresholdKeyShare{pub, y, i + 1}
+	}
+
+	return pub, shares, nil
+}
+
+// PartialDecrypt returns the server's partial decryption c^(2 * Delta * share)
+// of every coordinate of the encrypted vector where Delta = NumServers!
+func (key *ThresholdKeyShare) PartialDecrypt(a *EncryptedVec) (*PartialDecryption, error) {
+
+	scheme := key.PublicKey.Scheme
+	if !scheme.Equal(a.Scheme) {
+		return nil, errors.New("vector is not encrypted under the threshold public key")
+	}
+
+	exp := new(gmp.Int).Mul(key.PublicKey.delta(), key.Share)
+	exp.Lsh(exp, 1)
+
+	res := make([]*gmp.Int, len(a.Coords))
+	for i, coord := range a.Coords {
+		c, err := damgardJurikCiphertext(coord)
+		if err != nil {
+			return nil, err
+		}
+
+		res[i] = new(gmp.Int).Exp(c.C, exp, scheme.ns1)
+	}
+
+	return &PartialDecryption{res, key.Index}, nil
+}
+
+// Combine returns the decryption of the encrypted vector with coordinates in Z_{n^s}
+// from the partial decryptions of at least Threshold distinct servers.
+// Partial decryptions beyond the first Threshold are used to check the result:
+// each of them is combined with the first Threshold - 1 and must give the same decryption
+// throws an error if the partial decryptions are inconsistent (see ThresholdPublicKey)
+func (pub *ThresholdPublicKey) Combine(shares ...*PartialDecryption) (*BigVec, error) {
+
+	if len(shares) < pub.Threshold {
+		return nil, errors.New("not enough partial decryptions to decrypt")
+	}
+
+	indices := make(map[int]bool)
+	for _, share := range shares {
+		if share == nil || share.Index < 1 || share.Index > pub.NumServers || indices[share.Index] {
+			return nil, errors.New("partial decryptions must be from distinct servers")
+		}
+		indices[share.Index] = true
+
+		if len(share.Coords) != len(shares[0].Coords) {
+			return nil, errors.New("partial decryptions of different sized vectors")
+		}
+	}
+
+	res := pub.combine(shares[:pub.Threshold])
+
+	subset := append([]*PartialDecryption{}, shares[:pub.Threshold-1]...)
+	for _, extra := range shares[pub.Threshold:] {
+		if !pub.combine(append(subset, extra)).Equal(res) {
+			return nil, errors.New("partial decryptions are inconsistent")
+		}
+	}
+
+	return res, nil
+}
+
+// combine returns the decryption from exactly Threshold valid partial decryptions
+func (pub *ThresholdPublicKey) combine(shares []*PartialDecryption) *BigVec {
+
+	scheme := pub.Scheme
+	delta := pub.delta()
+	mu := pub.lagrangeCoefficients(shares)
+
+	// c^(4 * Delta^2 * d) = (1 + n)^(4 * Delta^2 * x) since d = 0 mod m and d = 1 mod n^s
+	scale := new(gmp.Int).Mul(delta, delta)
+	scale.Lsh(scale, 2)
+	scaleInv := new(gmp.Int).ModInverse(scale, scheme.ns)
+
+	res := make([]*gmp.Int, len(shares[0].Coords))
+	for j := range res {
+		c := gmp.NewInt(1)
+		for i, share := range shares {
+			exp := new(gmp.Int).Lsh(mu[i], 1)
+			base := share.Coords[j]
+			if exp.Sign() < 0 {
+				base = new(gmp.Int).ModInverse(base, scheme.ns1)
+				exp.Neg(exp)
+			}
+
+			c.Mul(c, new(gmp.Int).Exp(base, exp, scheme.ns1))
+			c.Mod(c, scheme.ns1)
+		}
+
+		x := scheme.log(c)
+		x.Mul(x, scaleInv)
+		res[j] = x.Mod(x, scheme.ns)
+	}
+
+	return NewBigVec(res)
+}
+
+// delta returns NumServers!
+func (pub *ThresholdPublicKey) delta() *gmp.Int {
+	delta := gmp.NewInt(1)
+	for i := 2; i <= pub.NumServers; i++ {
+		delta.Mul(delta, gmp.NewInt(int64(i)))
+	}
+	return delta
+}
+
+// lagrangeCoefficients returns the integer coefficients Delta * lambda_i(0)
+// for interpolating at zero from the indices of the shares
+func (pub *ThresholdPublicKey) lagrangeCoefficients(shares []*PartialDecryption) []*gmp.Int {
+
+	mu := make([]*gmp.Int, len(shares))
+	for i, si := range shares {
+		num := pub.delta()
+		den := gmp.NewInt(1)
+
+		for _, sj := range shares {
+			if sj.Index == si.Index {
+				continue
+			}
+
+			num.Mul(num, gmp.NewInt(int64(sj.Index)))
+			den.Mul(den, gmp.NewInt(int64(sj.Index-si.Index)))
+		}
+
+		// Delta is divisible by the denominator
+		mu[i] = num.Quo(num, den)
+	}
+
+	return mu
+}
+
+// safePrime returns a random safe prime p = 2p' + 1 of the given bit length and p'
+func safePrime(bits int) (*gmp.Int, *gmp.Int, error) {
+
+	for {
+		pp, err := rand.Prime(rand.Reader, bits-1)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		ppGmp := new(gmp.Int).SetBytes(pp.Bytes())
+		p := new(gmp.Int).Lsh(ppGmp, 1)
+		p.Add(p, gmp.NewInt(1))
+
+		// run 20 tests of Rabin-Miller
+		if p.ProbablyPrime(20) {
+			return p, ppGmp, nil
+		}
+	}
+}
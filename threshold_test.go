@@ -0,0 +1,111 @@
+package vec
+
+import (
+	"testing"
+
+	"github.com/ncw/gmp"
+)
+
+func TestThresholdPaillier(t *testing.T) {
+
+	numServers := 5
+	threshold := 3
+
+	pub, keys, err := NewThresholdPaillier(keyBits, numServers, threshold)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for trial := 0; trial < 5; trial++ {
+
+		aBig := NewBigRandomVec(dim, gmp.NewInt(-1000), gmp.NewInt(1000))
+		encA := EncryptWith(aBig, pub.Scheme)
+
+		partials := make([]*PartialDecryption, numServers)
+		for i, key := range keys {
+			partials[i], err = key.PartialDecrypt(encA)
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		// any threshold many servers can decrypt
+		subsets := [][]*PartialDecryption{
+			partials[:threshold],
+			partials[numServers-threshold:],
+			{partials[4], partials[0], partials[2]},
+			partials,
+		}
+
+		for _, subset := range subsets {
+			res, err := pub.Combine(subset...)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			res = res.DecodeSignedValues(pub.Scheme.PlaintextModulus())
+			if !res.Equal(aBig) {
+				t.Fatalf("Incorrest result. \nExpected %v \nGot %v", aBig, res)
+			}
+		}
+
+		if _, err := pub.Combine(partials[:threshold-1]...); err == nil {
+			t.Fatalf("Expected an error with fewer than threshold partial decryptions")
+		}
+
+		if _, err := pub.Combine(partials[0], partials[0], partials[1]); err == nil {
+			t.Fatalf("Expected an error with duplicate partial decryptions")
+		}
+
+		// duplicates beyond the first threshold many are rejected as well
+		if _, err := pub.Combine(partials[0], partials[1], partials[2], partials[1]); err == nil {
+			t.Fatalf("Expected an error with duplicate extra partial decryptions")
+		}
+
+		// an incorrect extra partial decryption is detected
+		cheat := &PartialDecryption{append([]*gmp.Int{}, partials[4].Coords...), partials[4].Index}
+		cheat.Coords[0] = new(gmp.Int).Mul(cheat.Coords[0], cheat.Coords[0])
+		if _, err := pub.Combine(partials[0], partials[1], partials[2], cheat); err == nil {
+			t.Fatalf("Expected an error with an inconsistent partial decryption")
+		}
+	}
+}
+
+func TestThresholdPaillierHomomorphic(t *testing.T) {
+
+	pub, keys, err := NewThresholdDamgardJurik(keyBits, 2, 3, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	aBig := NewBigRandomVec(dim, gmp.NewInt(-1000), gmp.NewInt(1000))
+	bBig := NewBigRandomVec(dim, gmp.NewInt(-1000), gmp.NewInt(1000))
+
+	sum, err := EncryptWith(aBig, pub.Scheme).Add(EncryptWith(bBig, pub.Scheme))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	p0, err := keys[0].PartialDecrypt(sum)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	p2, err := keys[2].PartialDecrypt(sum)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	res, err := pub.Combine(p2, p0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := aBig.Clone()
+	expected.Add(bBig)
+
+	res = res.DecodeSignedValues(pub.Scheme.PlaintextModulus())
+	if !res.Equal(expected) {
+		t.Fatalf("Incorrest result. \nExpected %v \nGot %v", expected, res)
+	}
+}
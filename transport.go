@@ -0,0 +1,250 @@
+package vec
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+	"net"
+	"sync"
+	"time"
+)
+
+// Transport sends and receives messages between parties
+// where parties are identified by the index of their shares
+type Transport interface {
+	Send(to int, msg []byte) error
+	Receive(from int) ([]byte, error)
+	Close() error
+}
+
+// errTransportClosed is returned when using a closed transport
+var errTransportClosed = errors.New("transport is closed")
+
+// ErrMessageTooLarge is returned when sending or receiving a message larger than MaxMessageSize
+var ErrMessageTooLarge = errors.New("message exceeds the maximum message size")
+
+// MaxMessageSize is the maximum size in bytes of a message sent over a TCPTransport
+// so that a party cannot make another party allocate an arbitrary amount of memory
+const MaxMessageSize = 1 << 28
+
+// MemoryTransport is an in-process transport backed by channels
+type MemoryTransport struct {
+	id       int
+	channels [][]chan []byte // channels[from][to]
+	done     chan struct{}
+	once     *sync.Once
+}
+
+// NewMemoryTransports returns connected in-process transports for each of the parties
+func NewMemoryTransports(numParties int) []*MemoryTransport {
+
+	channels := make([][]chan []byte, numParties)
+	for from := 0; from < numParties; from++ {
+		channels[from] = make([]chan []byte, numParties)
+		for to := 0; to < numParties; to++ {
+			channels[from][to] = make(chan []byte, 1)
+		}
+	}
+
+	done := make(chan struct{})
+	once := &sync.Once{}
+
+	transports := make([]*MemoryTransport, numParties)
+	for i := 0; i < numParties; i++ {
+		transports[i] = &MemoryTransport{i, channels, done, once}
+	}
+
+	return transports
+}
+
+// Send sends the message to the party
+func (t *MemoryTransport) Send(to int, msg []byte) error {
+
+	if to < 0 || to >= len(t.channels) {
+		return errors.New("unknown party")
+	}
+
+	select {
+	case t.channels[t.id][to] <- msg:
+		return nil
+	case <-t.done:
+		return errTransportClosed
+	}
+}
+
+// Receive returns the next message sent by the party
+func (t *MemoryTransport) Receive(from int) ([]byte, error) {
+
+	if from < 0 || from >= len(t.channels) {
+		return nil, errors.New("unknown party")
+	}
+
+	select {
+	case msg := <-t.channels[from][t.id]:
+		return msg, nil
+	case <-t.done:
+		return nil, errTransportClosed
+	}
+}
+
+// Close closes the transports of all parties
+func (t *MemoryTransport) Close() error {
+	t.once.Do(func() { close(t.done) })
+	return nil
+}
+
+// TCPTransport is a transport with one TCP connection to every other party
+type TCPTransport struct {
+	id    int
+	conns []net.Conn
+	locks []sync.Mutex
+}
+
+// tcpDialTimeout is how long to wait for the other parties to start listening
+const tcpDialTimeout = 10 * time.Second
+
+// ListenTCPTransport listens on addrs[id] and connects to all other parties
+func ListenTCPTransport(id int, addrs []string) (*TCPTransport, error) {
+
+	ln, err := net.Listen("tcp", addrs[id])
+	if err != nil {
+		return nil, err
+	}
+
+	return NewTCPTransport(id, ln, addrs)
+}
+
+// NewTCPTransport connects to all other parties where the party accepts
+// connections from parties with a smaller id on ln and dials parties with a larger id.
+// The listener is closed once all connections are established
+func NewTCPTransport(id int, ln net.Listener, addrs []string) (*TCPTransport, error) {
+
+	defer ln.Close()
+
+	t := &TCPTransport{
+		id:    id,
+		conns: make([]net.Conn, len(addrs)),
+		locks: make([]sync.Mutex, len(addrs)),
+	}
+
+	for to := id + 1; to < len(addrs); to++ {
+		conn, err := dialWithRetry(addrs[to])
+		if err != nil {
+			t.Close()
+			return nil, err
+		}
+
+		if err := binary.Write(conn, binary.BigEndian, uint32(id)); err != nil {
+			conn.Close()
+			t.Close()
+			return nil, err
+		}
+
+		t.conns[to] = conn
+	}
+
+	for accepted := 0; accepted < id; accepted++ {
+		conn, err := ln.Accept()
+		if err != nil {
+			t.Close()
+			return nil, err
+		}
+
+		var from uint32
+		if err := binary.Read(conn, binary.BigEndian, &from); err != nil {
+			conn.Close()
+			t.Close()
+			return nil, err
+		}
+
+		if int(from) >= id || t.conns[from] != nil {
+			conn.Close()
+			t.Close()
+			return nil, errors.New("unexpected connection from party")
+		}
+
+		t.conns[from] = conn
+	}
+
+	return t, nil
+}
+
+// Send sends the message to the party
+func (t *TCPTransport) Send(to int, msg []byte) error {
+
+	if to < 0 || to >= len(t.conns) || t.conns[to] == nil {
+		return errors.New("unknown party")
+	}
+
+	if len(msg) > MaxMessageSize {
+		return ErrMessageTooLarge
+	}
+
+	t.locks[to].Lock()
+	defer t.locks[to].Unlock()
+
+	buf := binary.BigEndian.AppendUint32(nil, uint32(len(msg)))
+	_, err := t.conns[to].Write(append(buf, msg...))
+
+	return err
+}
+
+// Receive returns the next message sent by the party
+func (t *TCPTransport) Receive(from int) ([]byte, error) {
+
+	if from < 0 || from >= len(t.conns) || t.conns[from] == nil {
+		return nil, errors.New("unknown party")
+	}
+
+	var size uint32
+	if err := binary.Read(t.conns[from], binary.BigEndian, &size); err != nil {
+		return nil, err
+	}
+
+	// the length is sent by the other party so it cannot be trusted
+	if size > MaxMessageSize {
+		return nil, ErrMessageTooLarge
+	}
+
+	msg := make([]byte, size)
+	if _, err := io.ReadFull(t.conns[from], msg); err != nil {
+		return nil, err
+	}
+
+	return msg, nil
+}
+
+// Close closes the connections to all other parties
+func (t *TCPTransport) Close() error {
+
+	var err error
+	for _, conn := range t.conns {
+		if conn == nil {
+			continue
+		}
+
+		if cerr := conn.Close(); cerr != nil && err == nil {
+			err = cerr
+		}
+	}
+
+	return err
+}
+
+// dialWithRetry dials the address until it succeeds or tcpDialTimeout expires
+func dialWithRetry(addr string) (net.Conn, error) {
+
+	deadline := time.Now().Add(tcpDialTimeout)
+	for {
+		conn, err := net.Dial("tcp", addr)
+		if err == nil {
+			return conn, nil
+		}
+
+		if time.Now().After(deadline) {
+			return nil, err
+		}
+
+		time.Sleep(50 * time.Millisecond)
+	}
+}
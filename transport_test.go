@@ -0,0 +1,78 @@
+package vec
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+)
+
+func TestMemoryTransport(t *testing.T) {
+
+	transports := NewMemoryTransports(2)
+
+	if err := transports[0].Send(1, []byte("hello")); err != nil {
+		t.Fatal(err)
+	}
+
+	msg, err := transports[1].Receive(0)
+	if err != nil || !bytes.Equal(msg, []byte("hello")) {
+		t.Fatalf("Expected hello, got %s (err = %v)", msg, err)
+	}
+
+	transports[0].Close()
+	if _, err := transports[1].Receive(0); err == nil {
+		t.Fatalf("Expected error receiving on closed transport")
+	}
+}
+
+func TestTCPTransport(t *testing.T) {
+
+	transports := tcpTransports(t, 3)
+	defer func() {
+		for _, transport := range transports {
+			transport.Close()
+		}
+	}()
+
+	for from := 0; from < 3; from++ {
+		for to := 0; to < 3; to++ {
+			if from == to {
+				continue
+			}
+
+			sent := []byte{byte(from), byte(to)}
+			if err := transports[from].Send(to, sent); err != nil {
+				t.Fatal(err)
+			}
+
+			msg, err := transports[to].Receive(from)
+			if err != nil || !bytes.Equal(msg, sent) {
+				t.Fatalf("Expected %v, got %v (err = %v)", sent, msg, err)
+			}
+		}
+	}
+}
+
+func TestTCPTransportMessageSize(t *testing.T) {
+
+	transports := tcpTransports(t, 2)
+	defer func() {
+		for _, transport := range transports {
+			transport.Close()
+		}
+	}()
+
+	if err := transports[0].Send(1, make([]byte, MaxMessageSize+1)); err != ErrMessageTooLarge {
+		t.Fatalf("Expected message too large error, got %v", err)
+	}
+
+	// a header announcing a message above the limit is rejected before allocating it
+	conn := transports[0].(*TCPTransport).conns[1]
+	if err := binary.Write(conn, binary.BigEndian, uint32(MaxMessageSize+1)); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := transports[1].Receive(0); err != ErrMessageTooLarge {
+		t.Fatalf("Expected message too large error, got %v", err)
+	}
+}
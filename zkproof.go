@@ -0,0 +1,568 @@
+package vec
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"strconv"
+
+	"github.com/ncw/gmp"
+	"github.com/sachaservan/paillier"
+)
+
+// ErrInvalidProof is returned when a zero-knowledge proof does not verify
+// or the encrypted vector it is attached to is malformed
+var ErrInvalidProof = errors.New("invalid zero-knowledge proof")
+
+// challengeBits is the bit length of the Fiat–Shamir challenges
+// (must be smaller than the bit length of the prime factors of n)
+const challengeBits = 128
+
+// NthPowerProof is a non-interactive proof of knowledge of r such that
+// u = r^n mod n^2, i.e. that u is a Paillier encryption of zero
+type NthPowerProof struct {
+	A *gmp.Int // commitment rho^n mod n^2
+	Z *gmp.Int // response rho * r^e mod n
+}
+
+// SetMembershipProof is a non-interactive OR-proof that a Paillier
+// ciphertext encrypts one value of a public set
+type SetMembershipProof struct {
+	A []*gmp.Int // commitments for every value of the set
+	E []*gmp.Int // challenges for every value of the set
+	Z []*gmp.Int // responses for every value of the set
+}
+
+// BinaryProof is a non-interactive proof that every coordinate
+// of a Paillier encrypted vector encrypts 0 or 1
+type BinaryProof struct {
+	Coords []*SetMembershipProof
+}
+
+// BitDecompositionProof proves that a Paillier ciphertext encrypts a value
+// in [0, 2^len(Bits)) using encryptions of the bits of the value
+type BitDecompositionProof struct {
+	Bits      []*paillier.Ciphertext
+	BitProofs []*SetMembershipProof
+	Sum       *NthPowerProof // proof that prod_j Bits_j^(2^j) encrypts the same value
+}
+
+// RangeProof is a non-interactive proof that every coordinate of a Paillier
+// encrypted vector encrypts a value x in [min, max] by proving that
+// both x - min and max - x are in [0, 2^k) where k is the bit length of max - min
+type RangeProof struct {
+	Lower []*BitDecompositionProof
+	Upper []*BitDecompositionProof
+}
+
+// EncryptBinaryWithProof returns a Paillier encryption of the binary vector
+// together with a proof that every coordinate is 0 or 1.
+// The proof is bound to the whole encrypted vector and to the context
+// (e.g. a session and sender identifier) so that it cannot be replayed elsewhere
+func EncryptBinaryWithProof(a *BigVec, pk *paillier.PublicKey, context string) (*EncryptedVec, *BinaryProof, error) {
+
+	values := []*gmp.Int{gmp.NewInt(0), gmp.NewInt(1)}
+
+	indices := make([]int, a.Size())
+	for i, coord := range a.Coords {
+		if indices[i] = setIndex(values, coord); indices[i] < 0 {
+			return nil, nil, errors.New("trying to prove a non-binary vector is binary")
+		}
+	}
+
+	ciphertexts, randomness := encryptAllWithRandomness(pk, a)
+	statement := transcript("binary", context, ciphertexts)
+
+	proof := &BinaryProof{make([]*SetMembershipProof, a.Size())}
+	for i, c := range ciphertexts {
+		proof.Coords[i] = proveSetMembership(pk, c.C, randomness[i], values, indices[i], statement, binaryLabel(i))
+	}
+
+	return NewEncryptedVec(NewPaillierHE(pk, nil), toCiphertexts(ciphertexts)), proof, nil
+}
+
+// VerifyBinary returns nil if the proof shows that every coordinate of
+// the Paillier encrypted vector encrypts 0 or 1 and was created for the context
+// and ErrInvalidProof otherwise
+func VerifyBinary(a *EncryptedVec, proof *BinaryProof, pk *paillier.PublicKey, context string) error {
+
+	if proof == nil || len(proof.Coords) != a.Size() {
+		return ErrInvalidProof
+	}
+
+	ciphertexts, err := validCiphertexts(pk, a)
+	if err != nil {
+		return err
+	}
+
+	values := []*gmp.Int{gmp.NewInt(0), gmp.NewInt(1)}
+	statement := transcript("binary", context, ciphertexts)
+
+	for i, c := range ciphertexts {
+		if !verifySetMembership(pk, c.C, values, proof.Coords[i], statement, binaryLabel(i)) {
+			return ErrInvalidProof
+		}
+	}
+
+	return nil
+}
+
+// EncryptRangeWithProof returns a Paillier encryption of the vector together
+// with a proof that every coordinate is in [min, max] bound to the context (see EncryptBinaryWithProof)
+func EncryptRangeWithProof(a *BigVec, pk *paillier.PublicKey, min *gmp.Int, max *gmp.Int, context string) (*EncryptedVec, *RangeProof, error) {
+
+	if err := checkRange(pk, min, max); err != nil {
+		return nil, nil, err
+	}
+
+	for _, coord := range a.Coords {
+		if coord.Cmp(min) < 0 || coord.Cmp(max) > 0 {
+			return nil, nil, errors.New("value is out of the range to prove")
+		}
+	}
+
+	bits := new(gmp.Int).Sub(max, min).BitLen()
+
+	ciphertexts, randomness := encryptAllWithRandomness(pk, a)
+	statement := transcript("range", context, ciphertexts, min, max)
+
+	proof := &RangeProof{
+		Lower: make([]*BitDecompositionProof, a.Size()),
+		Upper: make([]*BitDecompositionProof, a.Size()),
+	}
+
+	for i, c := range ciphertexts {
+		coord, r := a.Coords[i], randomness[i]
+
+		lower, upper := rangeBounds(pk, c.C, min, max)
+		rInv := new(gmp.Int).ModInverse(r, pk.N)
+
+		proof.Lower[i] = proveBitDecomposition(pk, lower, new(gmp.Int).Sub(coord, min), r, bits, statement, rangeLabel(i, "lower"))
+		proof.Upper[i] = proveBitDecomposition(pk, upper, new(gmp.Int).Sub(max, coord), rInv, bits, statement, rangeLabel(i, "upper"))
+	}
+
+	return NewEncryptedVec(NewPaillierHE(pk, nil), toCiphertexts(ciphertexts)), proof, nil
+}
+
+// VerifyRange returns nil if the proof shows that every coordinate of the Paillier
+// encrypted vector encrypts a value in [min, max] and was created for the context
+// and ErrInvalidProof otherwise
+func VerifyRange(a *EncryptedVec, proof *RangeProof, pk *paillier.PublicKey, min *gmp.Int, max *gmp.Int, context string) error {
+
+	if err := checkRange(pk, min, max); err != nil {
+		return err
+	}
+
+	if proof == nil || len(proof.Lower) != a.Size() || len(proof.Upper) != a.Size() {
+		return ErrInvalidProof
+	}
+
+	ciphertexts, err := validCiphertexts(pk, a)
+	if err != nil {
+		return err
+	}
+
+	bits := new(gmp.Int).Sub(max, min).BitLen()
+	statement := transcript("range", context, ciphertexts, min, max)
+
+	for i, c := range ciphertexts {
+		lower, upper := rangeBounds(pk, c.C, min, max)
+
+		if !verifyBitDecomposition(pk, lower, proof.Lower[i], bits, statement, rangeLabel(i, "lower")) ||
+			!verifyBitDecomposition(pk, upper, proof.Upper[i], bits, statement, rangeLabel(i, "upper")) {
+			return ErrInvalidProof
+		}
+	}
+
+	return nil
+}
+
+// proveBitDecomposition returns a proof that c = Enc(m; r) encrypts a value in [0, 2^bits)
+func proveBitDecomposition(pk *paillier.PublicKey, c *gmp.Int, m *gmp.Int, r *gmp.Int, bits int, statement []byte, label string) *BitDecompositionProof {
+
+	values := []*gmp.Int{gmp.NewInt(0), gmp.NewInt(1)}
+	nSquared := new(gmp.Int).Mul(pk.N, pk.N)
+
+	proof := &BitDecompositionProof{
+		Bits:      make([]*paillier.Ciphertext, bits),
+		BitProofs: make([]*SetMembershipProof, bits),
+	}
+
+	// randomness of prod_j Bits_j^(2^j) divided by r
+	sumR := new(gmp.Int).ModInverse(r, pk.N)
+
+	for j := 0; j < bits; j++ {
+		bit := gmp.NewInt(int64(m.Bit(j)))
+
+		b, rj := encryptWithRandomness(pk, bit)
+		proof.Bits[j] = b
+		proof.BitProofs[j] = proveSetMembership(pk, b.C, rj, values, int(bit.Int64()), statement, bitLabel(label, j))
+
+		rj.Exp(rj, new(gmp.Int).Lsh(gmp.NewInt(1), uint(j)), pk.N)
+		sumR.Mul(sumR, rj)
+		sumR.Mod(sumR, pk.N)
+	}
+
+	u := bitSum(pk, proof.Bits)
+	u.Mul(u, new(gmp.Int).ModInverse(c, nSquared))
+	u.Mod(u, nSquared)
+
+	proof.Sum = proveNthPower(pk, u, sumR, statement, label+"/sum")
+
+	return proof
+}
+
+// verifyBitDecomposition returns true if the proof shows that c encrypts a value in [0, 2^bits)
+func verifyBitDecomposition(pk *paillier.PublicKey, c *gmp.Int, proof *BitDecompositionProof, bits int, statement []byte, label string) bool {
+
+	if proof == nil || len(proof.Bits) != bits || len(proof.BitProofs) != bits || proof.Sum == nil {
+		return false
+	}
+
+	values := []*gmp.Int{gmp.NewInt(0), gmp.NewInt(1)}
+	nSquared := new(gmp.Int).Mul(pk.N, pk.N)
+
+	for j, b := range proof.Bits {
+		if b == nil || !validCiphertext(pk, b) {
+			return false
+		}
+
+		if !verifySetMembership(pk, b.C, values, proof.BitProofs[j], statement, bitLabel(label, j)) {
+			return false
+		}
+	}
+
+	u := bitSum(pk, proof.Bits)
+	u.Mul(u, new(gmp.Int).ModInverse(c, nSquared))
+	u.Mod(u, nSquared)
+
+	return verifyNthPower(pk, u, proof.Sum, statement, label+"/sum")
+}
+
+// proveSetMembership returns a proof that c = Enc(values[k]; r) encrypts one of the values
+func proveSetMembership(pk *paillier.PublicKey, c *gmp.Int, r *gmp.Int, values []*gmp.Int, k int, statement []byte, label string) *SetMembershipProof {
+
+	nSquared := new(gmp.Int).Mul(pk.N, pk.N)
+	challengeMod := new(gmp.Int).Lsh(gmp.NewInt(1), challengeBits)
+
+	proof := &SetMembershipProof{
+		A: make([]*gmp.Int, len(values)),
+		E: make([]*gmp.Int, len(values)),
+		Z: make([]*gmp.Int, len(values)),
+	}
+
+	// simulate the proofs for all other values of the set
+	simulated := gmp.NewInt(0)
+	for j, value := range values {
+		if j == k {
+			continue
+		}
+
+		proof.E[j] = newCryptoRandom(challengeMod)
+		proof.Z[j] = randomUnit(pk.N)
+		proof.A[j] = nthPowerCommitment(pk, proof.Z[j], shiftedCiphertext(pk, c, value), proof.E[j])
+		simulated.Add(simulated, proof.E[j])
+	}
+
+	rho := randomUnit(pk.N)
+	proof.A[k] = new(gmp.Int).Exp(rho, pk.N, nSquared)
+
+	e := setChallenge(pk, c, values, proof.A, statement, label)
+	proof.E[k] = e.Sub(e, simulated)
+	proof.E[k].Mod(proof.E[k], challengeMod)
+
+	proof.Z[k] = new(gmp.Int).Exp(r, proof.E[k], pk.N)
+	proof.Z[k].Mul(proof.Z[k], rho)
+	proof.Z[k].Mod(proof.Z[k], pk.N)
+
+	return proof
+}
+
+// verifySetMembership returns true if the proof shows that c encrypts one of the values
+func verifySetMembership(pk *paillier.PublicKey, c *gmp.Int, values []*gmp.Int, proof *SetMembershipProof, statement []byte, label string) bool {
+
+	if proof == nil || len(proof.A) != len(values) || len(proof.E) != len(values) || len(proof.Z) != len(values) {
+		return false
+	}
+
+	nSquared := new(gmp.Int).Mul(pk.N, pk.N)
+	challengeMod := new(gmp.Int).Lsh(gmp.NewInt(1), challengeBits)
+
+	sum := gmp.NewInt(0)
+	for j, value := range values {
+		if !inRange(proof.A[j], nSquared) || !inRange(proof.Z[j], pk.N) {
+			return false
+		}
+
+		if proof.E[j] == nil || proof.E[j].Sign() < 0 || proof.E[j].Cmp(challengeMod) >= 0 {
+			return false
+		}
+
+		expected := nthPowerCommitment(pk, proof.Z[j], shiftedCiphertext(pk, c, value), proof.E[j])
+		if expected.Cmp(proof.A[j]) != 0 {
+			return false
+		}
+
+		sum.Add(sum, proof.E[j])
+	}
+
+	e := setChallenge(pk, c, values, proof.A, statement, label)
+	return sum.Mod(sum, challengeMod).Cmp(e) == 0
+}
+
+// proveNthPower returns a proof of knowledge of r such that u = r^n mod n^2
+func proveNthPower(pk *paillier.PublicKey, u *gmp.Int, r *gmp.Int, statement []byte, label string) *NthPowerProof {
+
+	nSquared := new(gmp.Int).Mul(pk.N, pk.N)
+
+	rho := randomUnit(pk.N)
+	a := new(gmp.Int).Exp(rho, pk.N, nSquared)
+
+	e := challenge(pk, statement, label, u, a)
+
+	z := new(gmp.Int).Exp(r, e, pk.N)
+	z.Mul(z, rho)
+	z.Mod(z, pk.N)
+
+	return &NthPowerProof{a, z}
+}
+
+// verifyNthPower returns true if the proof shows that u is an n-th power mod n^2
+func verifyNthPower(pk *paillier.PublicKey, u *gmp.Int, proof *NthPowerProof, statement []byte, label string) bool {
+
+	nSquared := new(gmp.Int).Mul(pk.N, pk.N)
+
+	if proof == nil || !inRange(proof.A, nSquared) || !inRange(proof.Z, pk.N) {
+		return false
+	}
+
+	e := challenge(pk, statement, label, u, proof.A)
+
+	return nthPowerCommitment(pk, proof.Z, u, e).Cmp(proof.A) == 0
+}
+
+// nthPowerCommitment returns z^n * u^(-e) mod n^2, which is the
+// commitment of an accepting transcript (a, e, z) for u
+func nthPowerCommitment(pk *paillier.PublicKey, z *gmp.Int, u *gmp.Int, e *gmp.Int) *gmp.Int {
+
+	nSquared := new(gmp.Int).Mul(pk.N, pk.N)
+
+	a := new(gmp.Int).Exp(z, pk.N, nSquared)
+	ue := new(gmp.Int).Exp(u, e, nSquared)
+	a.Mul(a, ue.ModInverse(ue, nSquared))
+
+	return a.Mod(a, nSquared)
+}
+
+// shiftedCiphertext returns c / g^m mod n^2, which
+// is an n-th power if and only if c encrypts m
+func shiftedCiphertext(pk *paillier.PublicKey, c *gmp.Int, m *gmp.Int) *gmp.Int {
+
+	nSquared := new(gmp.Int).Mul(pk.N, pk.N)
+
+	g := generatorPow(pk, m, nSquared)
+	g.ModInverse(g, nSquared)
+
+	g.Mul(g, c)
+	return g.Mod(g, nSquared)
+}
+
+// rangeBounds returns encryptions of x - min and max - x from an encryption c of x
+func rangeBounds(pk *paillier.PublicKey, c *gmp.Int, min *gmp.Int, max *gmp.Int) (*gmp.Int, *gmp.Int) {
+
+	nSquared := new(gmp.Int).Mul(pk.N, pk.N)
+
+	lower := shiftedCiphertext(pk, c, min)
+
+	upper := shiftedCiphertext(pk, c, max)
+	upper.ModInverse(upper, nSquared)
+
+	return lower, upper
+}
+
+// bitSum returns prod_j bits_j^(2^j) mod n^2
+func bitSum(pk *paillier.PublicKey, bits []*paillier.Ciphertext) *gmp.Int {
+
+	nSquared := new(gmp.Int).Mul(pk.N, pk.N)
+
+	res := gmp.NewInt(1)
+	for j := len(bits) - 1; j >= 0; j-- {
+		res.Mul(res, res)
+		res.Mul(res, bits[j].C)
+		res.Mod(res, nSquared)
+	}
+
+	return res
+}
+
+// setChallenge returns the Fiat–Shamir challenge of a set membership proof
+func setChallenge(pk *paillier.PublicKey, c *gmp.Int, values []*gmp.Int, commitments []*gmp.Int, statement []byte, label string) *gmp.Int {
+	inputs := append([]*gmp.Int{c}, values...)
+	return challenge(pk, statement, label, append(inputs, commitments...)...)
+}
+
+// challenge returns the Fiat–Shamir challenge in [0, 2^challengeBits)
+// for the public key, statement (see transcript), label and values
+func challenge(pk *paillier.PublicKey, statement []byte, label string, values ...*gmp.Int) *gmp.Int {
+
+	h := sha256.New()
+	writeHashInput(h.Write, statement)
+	writeHashInput(h.Write, []byte(label))
+	writeHashInput(h.Write, pk.N.Bytes())
+	for _, v := range values {
+		writeHashInput(h.Write, v.Bytes())
+	}
+
+	digest := h.Sum(nil)
+	return new(gmp.Int).SetBytes(digest[:challengeBits/8])
+}
+
+// transcript returns a digest of the statement that every challenge of a proof is bound to:
+// the kind of proof, the caller's context, the public parameters and all ciphertexts of the vector
+func transcript(kind string, context string, ciphertexts []*paillier.Ciphertext, params ...*gmp.Int) []byte {
+
+	h := sha256.New()
+	writeHashInput(h.Write, []byte(kind))
+	writeHashInput(h.Write, []byte(context))
+
+	for _, param := range params {
+		writeHashInput(h.Write, []byte{byte(param.Sign() + 1)})
+		writeHashInput(h.Write, param.Bytes())
+	}
+
+	for _, c := range ciphertexts {
+		writeHashInput(h.Write, c.C.Bytes())
+	}
+
+	return h.Sum(nil)
+}
+
+// writeHashInput writes the length-prefixed input so that
+// different sequences of inputs do not collide
+func writeHashInput(write func([]byte) (int, error), input []byte) {
+	var length [4]byte
+	binary.BigEndian.PutUint32(length[:], uint32(len(input)))
+	write(length[:])
+	write(input)
+}
+
+// encryptWithRandomness returns a Paillier encryption of m and the randomness r used
+func encryptWithRandomness(pk *paillier.PublicKey, m *gmp.Int) (*paillier.Ciphertext, *gmp.Int) {
+
+	nSquared := new(gmp.Int).Mul(pk.N, pk.N)
+	r := randomUnit(pk.N)
+
+	// g^m * r^n mod n^2
+	c := generatorPow(pk, m, nSquared)
+	c.Mul(c, new(gmp.Int).Exp(r, pk.N, nSquared))
+	c.Mod(c, nSquared)
+
+	return &paillier.Ciphertext{C: c}, r
+}
+
+// encryptAllWithRandomness returns Paillier encryptions of the coordinates
+// of the vector and the randomness used for each of them
+func encryptAllWithRandomness(pk *paillier.PublicKey, a *BigVec) ([]*paillier.Ciphertext, []*gmp.Int) {
+
+	ciphertexts := make([]*paillier.Ciphertext, a.Size())
+	randomness := make([]*gmp.Int, a.Size())
+	for i, coord := range a.Coords {
+		ciphertexts[i], randomness[i] = encryptWithRandomness(pk, coord)
+	}
+
+	return ciphertexts, randomness
+}
+
+// toCiphertexts returns the Paillier ciphertexts as coordinates of an EncryptedVec
+func toCiphertexts(ciphertexts []*paillier.Ciphertext) []Ciphertext {
+
+	res := make([]Ciphertext, len(ciphertexts))
+	for i, c := range ciphertexts {
+		res[i] = c
+	}
+
+	return res
+}
+
+// validCiphertexts returns the coordinates of the vector as Paillier ciphertexts
+// throws ErrInvalidProof if a coordinate is not a valid ciphertext under pk
+func validCiphertexts(pk *paillier.PublicKey, a *EncryptedVec) ([]*paillier.Ciphertext, error) {
+
+	ciphertexts := make([]*paillier.Ciphertext, a.Size())
+	for i, coord := range a.Coords {
+		c, err := paillierCiphertext(coord)
+		if err != nil || !validCiphertext(pk, c) {
+			return nil, ErrInvalidProof
+		}
+		ciphertexts[i] = c
+	}
+
+	return ciphertexts, nil
+}
+
+// randomUnit returns a random element of Z_n^*
+func randomUnit(n *gmp.Int) *gmp.Int {
+
+	one := gmp.NewInt(1)
+	gcd := new(gmp.Int)
+
+	for {
+		r := newCryptoRandom(n)
+		if r.Sign() > 0 && gcd.GCD(nil, nil, r, n).Cmp(one) == 0 {
+			return r
+		}
+	}
+}
+
+// validCiphertext returns true if c is a unit of Z_{n^2}
+func validCiphertext(pk *paillier.PublicKey, c *paillier.Ciphertext) bool {
+	nSquared := new(gmp.Int).Mul(pk.N, pk.N)
+	return c.C != nil && inRange(c.C, nSquared)
+}
+
+// inRange returns true if 0 < x < n and x is coprime to n
+func inRange(x *gmp.Int, n *gmp.Int) bool {
+	if x == nil || x.Sign() <= 0 || x.Cmp(n) >= 0 {
+		return false
+	}
+	return new(gmp.Int).GCD(nil, nil, x, n).Cmp(gmp.NewInt(1)) == 0
+}
+
+// checkRange returns an error if [min, max] is not a valid range
+// of plaintexts for pk
+func checkRange(pk *paillier.PublicKey, min *gmp.Int, max *gmp.Int) error {
+
+	if min.Cmp(max) > 0 {
+		return errors.New("invalid range: min is larger than max")
+	}
+
+	// x - min and max - x must not wrap around the plaintext modulus
+	width := new(gmp.Int).Sub(max, min)
+	if width.BitLen()+1 >= pk.N.BitLen()-1 {
+		return errors.New("range is too large for the plaintext modulus")
+	}
+
+	return nil
+}
+
+// setIndex returns the index of x in values or -1 if x is not in values
+func setIndex(values []*gmp.Int, x *gmp.Int) int {
+	for k, value := range values {
+		if value.Cmp(x) == 0 {
+			return k
+		}
+	}
+	return -1
+}
+
+func binaryLabel(i int) string {
+	return "vec/binary/" + strconv.Itoa(i)
+}
+
+func rangeLabel(i int, side string) string {
+	return "vec/range/" + strconv.Itoa(i) + "/" + side
+}
+
+func bitLabel(label string, j int) string {
+	return label + "/bit/" + strconv.Itoa(j)
+}
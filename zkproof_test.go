@@ -0,0 +1,173 @@
+package vec
+
+import (
+	"testing"
+
+	"github.com/ncw/gmp"
+	"github.com/sachaservan/paillier"
+)
+
+func TestBinaryProof(t *testing.T) {
+
+	pk, sk := paillier.KeyGen(keyBits)
+
+	for trial := 0; trial < 5; trial++ {
+
+		a := NewRandomVec(dim, 0, 1)
+		if !a.IsBinary() {
+			t.Fatalf("Test vector is not binary")
+		}
+
+		aBig := a.ToBigVec(gmp.NewInt(1))
+		encA, proof, err := EncryptBinaryWithProof(aBig, pk, "session")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if err := VerifyBinary(encA, proof, pk, "session"); err != nil {
+			t.Fatalf("Valid proof rejected: %v", err)
+		}
+
+		res, err := Decrypt(encA, sk)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !res.Equal(aBig) {
+			t.Fatalf("Incorrest result. \nExpected %v \nGot %v", aBig, res)
+		}
+	}
+}
+
+func TestBinaryProofRejectsMalformed(t *testing.T) {
+
+	pk, _ := paillier.KeyGen(keyBits)
+
+	aBig := NewBigRandomVec(dim, gmp.NewInt(0), gmp.NewInt(1))
+	encA, proof, err := EncryptBinaryWithProof(aBig, pk, "session")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, _, err := EncryptBinaryWithProof(NewBigVec([]*gmp.Int{gmp.NewInt(2)}), pk, "session"); err == nil {
+		t.Fatalf("Expected an error for a non-binary vector")
+	}
+
+	// replace a coordinate by an encryption of 2
+	tampered := NewEncryptedVec(encA.Scheme, append([]Ciphertext{}, encA.Coords...))
+	tampered.Coords[0] = tampered.Scheme.Add(encA.Coords[0], tampered.Scheme.Encrypt(gmp.NewInt(2)))
+	if err := VerifyBinary(tampered, proof, pk, "session"); err != ErrInvalidProof {
+		t.Fatalf("Expected ErrInvalidProof for a tampered ciphertext, got %v", err)
+	}
+
+	// a proof for a different key
+	otherPk, _ := paillier.KeyGen(keyBits)
+	if err := VerifyBinary(encA, proof, otherPk, "session"); err != ErrInvalidProof {
+		t.Fatalf("Expected ErrInvalidProof for a different key, got %v", err)
+	}
+
+	// swapped coordinates
+	swapped := NewEncryptedVec(encA.Scheme, append([]Ciphertext{}, encA.Coords...))
+	swapped.Coords[0], swapped.Coords[1] = swapped.Coords[1], swapped.Coords[0]
+	if aBig.Coord(0).Cmp(aBig.Coord(1)) != 0 {
+		if err := VerifyBinary(swapped, proof, pk, "session"); err != ErrInvalidProof {
+			t.Fatalf("Expected ErrInvalidProof for swapped coordinates, got %v", err)
+		}
+	}
+
+	// malformed ciphertexts and proofs
+	zero := NewEncryptedVec(encA.Scheme, append([]Ciphertext{}, encA.Coords...))
+	zero.Coords[0] = &paillier.Ciphertext{C: gmp.NewInt(0)}
+	if err := VerifyBinary(zero, proof, pk, "session"); err != ErrInvalidProof {
+		t.Fatalf("Expected ErrInvalidProof for a malformed ciphertext, got %v", err)
+	}
+
+	if err := VerifyBinary(encA, &BinaryProof{proof.Coords[1:]}, pk, "session"); err != ErrInvalidProof {
+		t.Fatalf("Expected ErrInvalidProof for a truncated proof, got %v", err)
+	}
+
+	if err := VerifyBinary(encA, nil, pk, "session"); err != ErrInvalidProof {
+		t.Fatalf("Expected ErrInvalidProof for a missing proof, got %v", err)
+	}
+
+	// the proof is bound to the context
+	if err := VerifyBinary(encA, proof, pk, "other session"); err != ErrInvalidProof {
+		t.Fatalf("Expected ErrInvalidProof for a different context, got %v", err)
+	}
+
+	// and to the whole vector: a valid proof for a prefix does not carry over
+	prefix := NewBigVec(aBig.Coords[:2])
+	encPrefix, prefixProof, err := EncryptBinaryWithProof(prefix, pk, "session")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	replaced := NewEncryptedVec(encA.Scheme, append([]Ciphertext{}, encA.Coords...))
+	copy(replaced.Coords, encPrefix.Coords)
+	replacedProof := &BinaryProof{append([]*SetMembershipProof{}, proof.Coords...)}
+	copy(replacedProof.Coords, prefixProof.Coords)
+	if err := VerifyBinary(replaced, replacedProof, pk, "session"); err != ErrInvalidProof {
+		t.Fatalf("Expected ErrInvalidProof for coordinates proven in another vector, got %v", err)
+	}
+}
+
+func TestRangeProof(t *testing.T) {
+
+	pk, sk := paillier.KeyGen(keyBits)
+
+	min := gmp.NewInt(-100)
+	max := gmp.NewInt(1000)
+
+	for trial := 0; trial < 2; trial++ {
+
+		aBig := NewBigRandomVec(10, min, max)
+		aBig.Coords[0].Set(min)
+		aBig.Coords[1].Set(max)
+
+		encA, proof, err := EncryptRangeWithProof(aBig, pk, min, max, "session")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if err := VerifyRange(encA, proof, pk, min, max, "session"); err != nil {
+			t.Fatalf("Valid proof rejected: %v", err)
+		}
+
+		res, err := DecryptSigned(encA, sk)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !res.Equal(aBig) {
+			t.Fatalf("Incorrest result. \nExpected %v \nGot %v", aBig, res)
+		}
+
+		// the proof does not hold for a smaller range
+		if err := VerifyRange(encA, proof, pk, min, gmp.NewInt(999), "session"); err != ErrInvalidProof {
+			t.Fatalf("Expected ErrInvalidProof for a different range, got %v", err)
+		}
+	}
+
+	if _, _, err := EncryptRangeWithProof(NewBigVec([]*gmp.Int{gmp.NewInt(1001)}), pk, min, max, "session"); err == nil {
+		t.Fatalf("Expected an error for a value out of range")
+	}
+
+	// shift an encrypted coordinate out of the range
+	encA, proof, err := EncryptRangeWithProof(NewBigVec([]*gmp.Int{gmp.NewInt(1000)}), pk, min, max, "session")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tampered, err := encA.AddPlain(NewBigVec([]*gmp.Int{gmp.NewInt(1)}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := VerifyRange(tampered, proof, pk, min, max, "session"); err != ErrInvalidProof {
+		t.Fatalf("Expected ErrInvalidProof for a tampered ciphertext, got %v", err)
+	}
+
+	if err := VerifyRange(encA, proof, pk, min, max, "other session"); err != ErrInvalidProof {
+		t.Fatalf("Expected ErrInvalidProof for a different context, got %v", err)
+	}
+}